@@ -0,0 +1,16 @@
+module github.com/LdDl/mot-go/cmd/mot-demo
+
+go 1.18
+
+require (
+	github.com/LdDl/mot-go v0.0.0
+	gocv.io/x/gocv v0.35.0
+)
+
+require (
+	github.com/google/uuid v1.4.0 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+)
+
+// Demo is built against the library from this repository
+replace github.com/LdDl/mot-go => ../../
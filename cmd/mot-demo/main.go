@@ -0,0 +1,175 @@
+// Command mot-demo reads webcam or RTSP stream, detects objects via YOLOv8-like ONNX model, tracks them and shows annotated frames.
+//
+// It depends on OpenCV 4 and GoCV (https://gocv.io/getting-started/), which are not required by the library itself,
+// so the command is a separate module (library is taken from this repository via replace directive):
+//
+//	cd cmd/mot-demo
+//	go mod tidy
+//	go run . -source 0 -model yolov8n.onnx
+//
+// Detector is not bundled: model file must be provided by user. It is expected to have single output of shape [1, 4 + classes, anchors]
+// (e.g. YOLOv8 exported via "yolo export model=yolov8n.pt format=onnx")
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/LdDl/mot-go/mot"
+	"gocv.io/x/gocv"
+)
+
+func main() {
+	source := flag.String("source", "0", "Webcam index or video URL (e.g. rtsp://...)")
+	modelPath := flag.String("model", "yolov8n.onnx", "Path to ONNX detector")
+	inputSize := flag.Int("size", 640, "Detector's input size")
+	confThreshold := flag.Float64("conf", 0.4, "Min confidence of detections")
+	nmsThreshold := flag.Float64("nms", 0.45, "IoU threshold of non-maximum suppression")
+	classNames := flag.String("classes", "", "Comma-separated class names (class indices are shown if empty)")
+	flag.Parse()
+
+	var capture *gocv.VideoCapture
+	var err error
+	if index, convErr := strconv.Atoi(*source); convErr == nil {
+		capture, err = gocv.OpenVideoCapture(index)
+	} else {
+		capture, err = gocv.OpenVideoCapture(*source)
+	}
+	if err != nil {
+		log.Fatalf("Can't open source '%s': %s", *source, err.Error())
+	}
+	defer capture.Close()
+
+	net := gocv.ReadNetFromONNX(*modelPath)
+	if net.Empty() {
+		log.Fatalf("Can't read model '%s'", *modelPath)
+	}
+	defer net.Close()
+
+	names := []string{}
+	if *classNames != "" {
+		names = strings.Split(*classNames, ",")
+	}
+
+	tracker := mot.NewSimpleTracker(
+		mot.WithMinDistThreshold(50.0),
+		mot.WithMaxNoMatch(15),
+		mot.WithDistanceMode(mot.DistanceMin),
+		mot.WithNMSThreshold(*nmsThreshold),
+		mot.WithMinConsecutiveMatches(3),
+		mot.WithDisplayIDs(mot.DisplayIDSequential),
+	)
+
+	window := gocv.NewWindow("mot-demo")
+	defer window.Close()
+	img := gocv.NewMat()
+	defer img.Close()
+
+	for {
+		if ok := capture.Read(&img); !ok {
+			log.Println("Stream has ended")
+			return
+		}
+		if img.Empty() {
+			continue
+		}
+		detections, err := detect(&net, img, *inputSize, float32(*confThreshold), names)
+		if err != nil {
+			log.Fatalf("Can't detect objects: %s", err.Error())
+		}
+		err = tracker.MatchObjects(detections)
+		if err != nil {
+			log.Fatalf("Can't match objects: %s", err.Error())
+		}
+		draw(&img, tracker.GetActiveTracks())
+		window.IMShow(img)
+		if window.WaitKey(1) == 27 {
+			return
+		}
+	}
+}
+
+// detect runs detector on frame and converts its output to blobs in frame coordinates
+func detect(net *gocv.Net, img gocv.Mat, inputSize int, confThreshold float32, names []string) ([]*mot.SimpleBlob, error) {
+	blob := gocv.BlobFromImage(img, 1.0/255.0, image.Pt(inputSize, inputSize), gocv.NewScalar(0, 0, 0, 0), true, false)
+	defer blob.Close()
+	net.SetInput(blob, "")
+	output := net.Forward("")
+	defer output.Close()
+
+	sizes := output.Size()
+	if len(sizes) != 3 || sizes[1] < 5 {
+		return nil, fmt.Errorf("unexpected output shape %v", sizes)
+	}
+	attributes, anchors := sizes[1], sizes[2]
+	data, err := output.DataPtrFloat32()
+	if err != nil {
+		return nil, err
+	}
+	resize := mot.NewResize(float64(img.Cols()), float64(img.Rows()), float64(inputSize), float64(inputSize))
+	detections := make([]*mot.SimpleBlob, 0)
+	for anchor := 0; anchor < anchors; anchor++ {
+		bestClass, bestScore := -1, confThreshold
+		for class := 0; class < attributes-4; class++ {
+			score := data[(4+class)*anchors+anchor]
+			if score > bestScore {
+				bestClass, bestScore = class, score
+			}
+		}
+		if bestClass < 0 {
+			continue
+		}
+		cx := float64(data[anchor])
+		cy := float64(data[anchors+anchor])
+		w := float64(data[2*anchors+anchor])
+		h := float64(data[3*anchors+anchor])
+		bbox := resize.ToOriginal(mot.Rectangle{X: cx - w/2.0, Y: cy - h/2.0, Width: w, Height: h})
+		detection := mot.NewSimpleBlob(bbox)
+		detection.SetConfidence(float64(bestScore))
+		if bestClass < len(names) {
+			detection.SetClass(names[bestClass])
+		} else {
+			detection.SetClass(strconv.Itoa(bestClass))
+		}
+		detections = append(detections, detection)
+	}
+	return detections, nil
+}
+
+// draw renders bounding boxes, labels and trajectories of tracks
+func draw(img *gocv.Mat, tracks []*mot.SimpleBlob) {
+	for _, track := range tracks {
+		trackColor := palette(track.GetDisplayID())
+		bbox := track.GetBBox()
+		rect := image.Rect(int(bbox.X), int(bbox.Y), int(bbox.X+bbox.Width), int(bbox.Y+bbox.Height))
+		gocv.Rectangle(img, rect, trackColor, 2)
+		label := fmt.Sprintf("%d %s", track.GetDisplayID(), track.GetClass())
+		gocv.PutText(img, label, image.Pt(rect.Min.X, rect.Min.Y-5), gocv.FontHersheySimplex, 0.5, trackColor, 1)
+		points := track.GetTrack()
+		for i := 1; i < len(points); i++ {
+			from := image.Pt(int(points[i-1].X), int(points[i-1].Y))
+			to := image.Pt(int(points[i].X), int(points[i].Y))
+			gocv.Line(img, from, to, trackColor, 1)
+		}
+	}
+}
+
+// palette returns distinct color for display identifier
+func palette(id int) color.RGBA {
+	colors := []color.RGBA{
+		{R: 230, G: 25, B: 75, A: 0},
+		{R: 60, G: 180, B: 75, A: 0},
+		{R: 255, G: 225, B: 25, A: 0},
+		{R: 0, G: 130, B: 200, A: 0},
+		{R: 245, G: 130, B: 48, A: 0},
+		{R: 145, G: 30, B: 180, A: 0},
+		{R: 70, G: 240, B: 240, A: 0},
+		{R: 240, G: 50, B: 230, A: 0},
+	}
+	return colors[id%len(colors)]
+}
@@ -3,9 +3,6 @@ module github.com/LdDl/mot-go
 go 1.18
 
 require (
-	github.com/LdDl/kalman-filter v0.2.1
 	github.com/google/uuid v1.4.0
 	github.com/pkg/errors v0.9.1
 )
-
-require gonum.org/v1/gonum v0.14.0 // indirect
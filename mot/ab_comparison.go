@@ -0,0 +1,217 @@
+package mot
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ABDivergence is per-frame difference of association between baseline and candidate trackers
+type ABDivergence struct {
+	// Number of detections which have been matched with existing track by one tracker, but have started new track (or have been rejected) in another one
+	OutcomeMismatches int
+	// Number of detections which have been assigned to tracks that are not the counterparts of each other
+	// (counterparts are established by the first detection which both tracks take)
+	IdentityMismatches int
+}
+
+// ABFrame is outcome of processing single frame by both trackers
+type ABFrame struct {
+	// Association report of baseline tracker
+	Baseline *MatchResult
+	// Association report of candidate tracker. It is nil if CandidateErr is not nil
+	Candidate *MatchResult
+	// Error of candidate tracker (it does not interrupt processing)
+	CandidateErr error
+	// Latencies of both trackers
+	BaselineLatency  time.Duration
+	CandidateLatency time.Duration
+	Divergence       ABDivergence
+}
+
+// ABStats is accumulated comparison of baseline and candidate trackers
+type ABStats struct {
+	Frames             int
+	Detections         int
+	OutcomeMismatches  int
+	IdentityMismatches int
+	// Number of frames on which candidate tracker has failed
+	CandidateErrors int
+	// Total number of created tracks
+	BaselineCreated  int
+	CandidateCreated int
+	// Average latencies per frame
+	BaselineAvgLatency  time.Duration
+	CandidateAvgLatency time.Duration
+}
+
+// ABComparison feeds the same detections to baseline (production) and candidate trackers concurrently and measures how their outputs diverge,
+// so new parameters could be evaluated on live data. Baseline tracker receives original detections, candidate one receives their copies
+// (trackers modify detections). Candidate's errors are recorded, but never returned. It is safe for concurrent use, but frames are processed one by one
+type ABComparison struct {
+	mu        sync.Mutex
+	baseline  FrameMatcher
+	candidate FrameMatcher
+	// Counterparts of baseline tracks among candidate tracks and vice versa
+	counterparts        map[uuid.UUID]counterpart
+	reverseCounterparts map[uuid.UUID]counterpart
+	stats               ABStats
+	baselineLatency     time.Duration
+	candidateLatency    time.Duration
+}
+
+// NewABComparison creates comparison of two trackers
+func NewABComparison(baseline, candidate FrameMatcher) *ABComparison {
+	return &ABComparison{
+		baseline:            baseline,
+		candidate:           candidate,
+		counterparts:        make(map[uuid.UUID]counterpart),
+		reverseCounterparts: make(map[uuid.UUID]counterpart),
+	}
+}
+
+// MatchObjects processes frame by both trackers concurrently. Returns error of baseline tracker only
+// (frame with invalid detections is rejected before it is passed to trackers)
+func (comparison *ABComparison) MatchObjects(ctx context.Context, newObjects []*SimpleBlob) (*ABFrame, error) {
+	comparison.mu.Lock()
+	defer comparison.mu.Unlock()
+	if err := validateBlobs(newObjects); err != nil {
+		return nil, err
+	}
+	copies := make([]*SimpleBlob, len(newObjects))
+	for i, newObject := range newObjects {
+		copies[i] = newObject.detectionCopy()
+	}
+	frame := &ABFrame{}
+	var baselineErr error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		start := time.Now()
+		frame.Baseline, baselineErr = comparison.baseline.MatchObjectsWithResultCtx(ctx, newObjects)
+		frame.BaselineLatency = time.Since(start)
+	}()
+	go func() {
+		defer wg.Done()
+		start := time.Now()
+		frame.Candidate, frame.CandidateErr = comparison.candidate.MatchObjectsWithResultCtx(ctx, copies)
+		frame.CandidateLatency = time.Since(start)
+	}()
+	wg.Wait()
+	if baselineErr != nil {
+		return nil, baselineErr
+	}
+	comparison.stats.Frames++
+	comparison.stats.Detections += len(newObjects)
+	comparison.stats.BaselineCreated += len(frame.Baseline.Created)
+	comparison.baselineLatency += frame.BaselineLatency
+	comparison.candidateLatency += frame.CandidateLatency
+	if frame.CandidateErr != nil {
+		comparison.stats.CandidateErrors++
+		return frame, nil
+	}
+	comparison.stats.CandidateCreated += len(frame.Candidate.Created)
+	frame.Divergence = comparison.compare(frame.Baseline, frame.Candidate, len(newObjects))
+	comparison.stats.OutcomeMismatches += frame.Divergence.OutcomeMismatches
+	comparison.stats.IdentityMismatches += frame.Divergence.IdentityMismatches
+	return frame, nil
+}
+
+// counterpartTTL is number of frames after which pair of tracks which have not taken any detection is forgotten
+const counterpartTTL = 1000
+
+// counterpart is track of another tracker which corresponds to some track
+type counterpart struct {
+	track uuid.UUID
+	// The latest frame on which both tracks have taken the same detection
+	lastFrame int
+}
+
+// detectionOutcome is what tracker has done with detection
+type detectionOutcome struct {
+	matched bool
+	track   uuid.UUID
+}
+
+// outcomes returns outcome of each detection (zero track means that detection has been rejected)
+func outcomes(result *MatchResult, n int) []detectionOutcome {
+	values := make([]detectionOutcome, n)
+	for _, matched := range result.Matched {
+		if matched.DetectionIndex < n {
+			values[matched.DetectionIndex] = detectionOutcome{matched: true, track: matched.TrackID}
+		}
+	}
+	for _, created := range result.Created {
+		if created.DetectionIndex < n {
+			values[created.DetectionIndex] = detectionOutcome{track: created.TrackID}
+		}
+	}
+	return values
+}
+
+// compare evaluates divergence of association reports and updates counterparts of tracks
+func (comparison *ABComparison) compare(baseline, candidate *MatchResult, n int) ABDivergence {
+	divergence := ABDivergence{}
+	baselineOutcomes := outcomes(baseline, n)
+	candidateOutcomes := outcomes(candidate, n)
+	zero := uuid.UUID{}
+	for i := 0; i < n; i++ {
+		a, b := baselineOutcomes[i], candidateOutcomes[i]
+		if a.matched != b.matched || (a.track == zero) != (b.track == zero) {
+			divergence.OutcomeMismatches++
+		}
+		if a.track == zero || b.track == zero {
+			continue
+		}
+		forward, okA := comparison.counterparts[a.track]
+		reverse, okB := comparison.reverseCounterparts[b.track]
+		if (okA && okB && forward.track == b.track && reverse.track == a.track) || (!okA && !okB) {
+			comparison.counterparts[a.track] = counterpart{track: b.track, lastFrame: comparison.stats.Frames}
+			comparison.reverseCounterparts[b.track] = counterpart{track: a.track, lastFrame: comparison.stats.Frames}
+			continue
+		}
+		divergence.IdentityMismatches++
+	}
+	if comparison.stats.Frames%counterpartTTL == 0 {
+		comparison.forgetCounterparts()
+	}
+	return divergence
+}
+
+// forgetCounterparts removes pairs of tracks which have not taken any detection for a long time (most likely they have been removed)
+func (comparison *ABComparison) forgetCounterparts() {
+	for track, value := range comparison.counterparts {
+		if comparison.stats.Frames-value.lastFrame > counterpartTTL {
+			delete(comparison.counterparts, track)
+		}
+	}
+	for track, value := range comparison.reverseCounterparts {
+		if comparison.stats.Frames-value.lastFrame > counterpartTTL {
+			delete(comparison.reverseCounterparts, track)
+		}
+	}
+}
+
+// Stats returns accumulated comparison
+func (comparison *ABComparison) Stats() ABStats {
+	comparison.mu.Lock()
+	defer comparison.mu.Unlock()
+	stats := comparison.stats
+	if stats.Frames > 0 {
+		stats.BaselineAvgLatency = comparison.baselineLatency / time.Duration(stats.Frames)
+		stats.CandidateAvgLatency = comparison.candidateLatency / time.Duration(stats.Frames)
+	}
+	return stats
+}
+
+// detectionCopy returns independent copy of detection (geometry, confidence, class and appearance)
+func (blob *SimpleBlob) detectionCopy() *SimpleBlob {
+	detection := NewSimpleBlobWithCenterTime(blob.currentCenter, blob.currentBBox, blob.tracker.dt)
+	detection.confidence = blob.confidence
+	detection.class = blob.class
+	detection.feature = blob.feature
+	return detection
+}
@@ -0,0 +1,73 @@
+package mot
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestABComparison(t *testing.T) {
+	baseline := NewSimpleTracker(WithMinDistThreshold(30.0))
+	// Candidate threshold is too small for objects which move by 10 pixels per frame
+	candidate := NewSimpleTracker(WithMinDistThreshold(1.0))
+	comparison := NewABComparison(baseline, candidate)
+	for i := 0; i < 5; i++ {
+		detections := []*SimpleBlob{
+			NewSimpleBlob(Rectangle{X: 10.0 + 10.0*float64(i), Y: 10.0, Width: 10.0, Height: 10.0}),
+			NewSimpleBlob(Rectangle{X: 10.0 + 10.0*float64(i), Y: 200.0, Width: 10.0, Height: 10.0}),
+		}
+		frame, err := comparison.MatchObjects(context.Background(), detections)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if frame.CandidateErr != nil {
+			t.Error(frame.CandidateErr)
+			return
+		}
+		if i > 0 && frame.Divergence.OutcomeMismatches != 2 {
+			t.Errorf("incorrect outcome mismatches on frame %d: %v, expected: %v", i, frame.Divergence.OutcomeMismatches, 2)
+		}
+	}
+	if len(baseline.Objects) != 2 {
+		t.Errorf("incorrect number of baseline tracks: %v, expected: %v", len(baseline.Objects), 2)
+	}
+	stats := comparison.Stats()
+	if stats.Frames != 5 || stats.Detections != 10 {
+		t.Errorf("incorrect number of frames and detections: %v, %v, expected: %v, %v", stats.Frames, stats.Detections, 5, 10)
+	}
+	if stats.BaselineCreated != 2 || stats.CandidateCreated != 10 {
+		t.Errorf("incorrect number of created tracks: %v, %v, expected: %v, %v", stats.BaselineCreated, stats.CandidateCreated, 2, 10)
+	}
+	if stats.OutcomeMismatches != 8 || stats.IdentityMismatches != 8 {
+		t.Errorf("incorrect mismatches: %v, %v, expected: %v, %v", stats.OutcomeMismatches, stats.IdentityMismatches, 8, 8)
+	}
+
+	// Identical configurations do not diverge
+	same := NewABComparison(NewSimpleTracker(), NewSimpleTracker())
+	for i := 0; i < 5; i++ {
+		_, err := same.MatchObjects(context.Background(), []*SimpleBlob{NewSimpleBlob(Rectangle{X: 10.0 + 5.0*float64(i), Y: 10.0, Width: 10.0, Height: 10.0})})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if stats := same.Stats(); stats.OutcomeMismatches != 0 || stats.IdentityMismatches != 0 {
+		t.Errorf("identical trackers should not diverge: %+v", stats)
+	}
+}
+
+func TestABComparisonInvalidBlob(t *testing.T) {
+	baseline := NewSimpleTracker(WithMinDistThreshold(30.0))
+	candidate := NewSimpleTracker(WithMinDistThreshold(1.0))
+	comparison := NewABComparison(baseline, candidate)
+	for _, detections := range [][]*SimpleBlob{{&SimpleBlob{}}, {nil}} {
+		_, err := comparison.MatchObjects(context.Background(), detections)
+		if !errors.Is(err, ErrInvalidBBox) {
+			t.Errorf("incorrect error: %v, expected: %v", err, ErrInvalidBBox)
+		}
+	}
+	if stats := comparison.Stats(); stats.Frames != 0 {
+		t.Errorf("incorrect number of frames: %d, expected: %d", stats.Frames, 0)
+	}
+}
@@ -0,0 +1,41 @@
+package mot
+
+// SetAdaptiveThreshold makes distance threshold depend on each track: threshold becomes
+//
+//	minDistThreshold + diagonalScale * track's diagonal + speedScale * track's speed
+//
+// where speed is the distance (in pixels) which track has passed during the last frame.
+// It allows single tracker to handle both near (large) and far (small) objects. Zeroes disable adaptation
+func (tracker *SimpleTracker) SetAdaptiveThreshold(diagonalScale, speedScale float64) {
+	tracker.diagonalGateScale = diagonalScale
+	tracker.speedGateScale = speedScale
+}
+
+// GetAdaptiveThreshold returns factors of track's diagonal and speed which are used in distance threshold
+func (tracker *SimpleTracker) GetAdaptiveThreshold() (float64, float64) {
+	return tracker.diagonalGateScale, tracker.speedGateScale
+}
+
+// gateThreshold returns distance threshold for given track. Track could be nil
+func (tracker *SimpleTracker) gateThreshold(object *SimpleBlob) float64 {
+	threshold := tracker.minDistThreshold
+	if object == nil {
+		return threshold
+	}
+	if tracker.diagonalGateScale != 0 {
+		threshold += tracker.diagonalGateScale * object.diagonal
+	}
+	if tracker.speedGateScale != 0 {
+		threshold += tracker.speedGateScale * object.lastDisplacement()
+	}
+	return threshold
+}
+
+// lastDisplacement returns distance between two last points of the track. Returns zero if there is no such points
+func (blob *SimpleBlob) lastDisplacement() float64 {
+	n := len(blob.track)
+	if n < 2 {
+		return 0
+	}
+	return euclideanDistance(blob.track[n-2], blob.track[n-1])
+}
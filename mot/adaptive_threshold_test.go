@@ -0,0 +1,31 @@
+package mot
+
+import (
+	"testing"
+)
+
+func TestAdaptiveThreshold(t *testing.T) {
+	for _, adaptive := range []bool{false, true} {
+		tracker := NewSimpleTracker(WithMinDistThreshold(5.0), WithMaxNoMatch(10))
+		if adaptive {
+			tracker.SetAdaptiveThreshold(0.5, 0.0)
+		}
+		// Large (near) object moves by 40 pixels between frames: far more than global threshold, but less than half of its diagonal
+		large := NewSimpleBlob(Rectangle{X: 100.0, Y: 100.0, Width: 120.0, Height: 120.0})
+		err := tracker.MatchObjects([]*SimpleBlob{large})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		// Detection itself is small (partially visible object), so its own diagonal does not help
+		moved := NewSimpleBlob(Rectangle{X: 195.0, Y: 155.0, Width: 10.0, Height: 10.0})
+		result, err := tracker.MatchObjectsWithResult([]*SimpleBlob{moved})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if (len(result.Matched) == 1) != adaptive {
+			t.Errorf("incorrect number of matched tracks (adaptive=%v): %d, expected: %v", adaptive, len(result.Matched), adaptive)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package mot
+
+// AppearanceBlob is implemented by blobs which carry appearance feature (e.g. ReID embedding of the detection).
+// Code which works with arbitrary blob types could check for it via type assertion. SimpleTracker reads features of SimpleBlob directly
+type AppearanceBlob interface {
+	// GetFeature returns appearance feature. Empty feature means that appearance is unknown
+	GetFeature() []float32
+	// SetFeature sets appearance feature
+	SetFeature(feature []float32)
+}
+
+var _ AppearanceBlob = (*SimpleBlob)(nil)
+
+// GetFeature returns blob's appearance feature. Be careful: this is not copy of feature, but reference to it
+func (blob *SimpleBlob) GetFeature() []float32 {
+	return blob.feature
+}
+
+// SetFeature sets blob's appearance feature. Slice is not copied, so it should not be modified afterwards.
+// When track is matched with detection which has feature, track takes detection's feature
+func (blob *SimpleBlob) SetFeature(feature []float32) {
+	blob.feature = feature
+}
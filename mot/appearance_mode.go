@@ -0,0 +1,25 @@
+package mot
+
+import "time"
+
+// SetAppearanceOnly switches tracker to appearance-only association: motion is ignored and detections are matched
+// with tracks by appearance features only (minimum cosine distance over track's gallery must not exceed maxCosineDistance).
+// Tracks which have not been seen for longer than window are removed (zero keeps max no match as the only limit).
+// It suits photo bursts or very low frame rate snapshots where Kalman prediction is meaningless.
+// Detections without features can't be matched in this mode
+func (tracker *SimpleTracker) SetAppearanceOnly(maxCosineDistance float64, window time.Duration) {
+	tracker.SetDistanceMode(DistanceAppearance)
+	tracker.SetAppearanceGate(maxCosineDistance)
+	tracker.SetMaxIdleTime(window)
+}
+
+// SetMaxIdleTime sets max time (according to frames timestamps) since the last match after which track is removed.
+// It works along with max no match. Pinned tracks are not removed. Zero disables the check
+func (tracker *SimpleTracker) SetMaxIdleTime(maxIdleTime time.Duration) {
+	tracker.maxIdleTime = maxIdleTime
+}
+
+// GetMaxIdleTime returns max time since the last match after which track is removed
+func (tracker *SimpleTracker) GetMaxIdleTime() time.Duration {
+	return tracker.maxIdleTime
+}
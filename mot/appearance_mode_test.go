@@ -0,0 +1,57 @@
+package mot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAppearanceOnly(t *testing.T) {
+	tracker := NewSimpleTracker(WithMaxNoMatch(100), WithFeatureBudget(5))
+	tracker.SetAppearanceOnly(0.2, time.Minute)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	newBlob := func(x float64, feature []float32) *SimpleBlob {
+		blob := NewSimpleBlob(Rectangle{X: x, Y: 10.0, Width: 20.0, Height: 40.0})
+		blob.SetFeature(feature)
+		return blob
+	}
+	result, err := tracker.MatchObjectsWithResultAt(start, []*SimpleBlob{
+		newBlob(10.0, []float32{1, 0, 0}),
+		newBlob(50.0, []float32{0, 1, 0}),
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	firstID, secondID := result.Created[0].TrackID, result.Created[1].TrackID
+	// Objects have swapped places and moved far away: only appearance could match them
+	result, err = tracker.MatchObjectsWithResultAt(start.Add(10*time.Second), []*SimpleBlob{
+		newBlob(900.0, []float32{0, 0.95, 0.05}),
+		newBlob(500.0, []float32{0.95, 0.05, 0}),
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result.Matched) != 2 || len(result.Created) != 0 {
+		t.Errorf("incorrect association by appearance: %v", result)
+		return
+	}
+	for _, matched := range result.Matched {
+		expected := secondID
+		if matched.DetectionIndex == 1 {
+			expected = firstID
+		}
+		if matched.TrackID != expected {
+			t.Errorf("incorrect track for detection %d: %s, expected: %s", matched.DetectionIndex, matched.TrackID, expected)
+		}
+	}
+	// Tracks are not seen for longer than window
+	err = tracker.MatchObjectsAt(start.Add(2*time.Minute), []*SimpleBlob{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(tracker.Objects) != 0 {
+		t.Errorf("incorrect number of tracks after window: %d, expected: %d", len(tracker.Objects), 0)
+	}
+}
@@ -0,0 +1,46 @@
+package mot
+
+import (
+	"testing"
+)
+
+func TestAppearanceFeature(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10))
+	detection := NewSimpleBlob(Rectangle{X: 10.0, Y: 10.0, Width: 20.0, Height: 20.0})
+	var blob interface{} = detection
+	appearance, ok := blob.(AppearanceBlob)
+	if !ok {
+		t.Errorf("SimpleBlob should implement AppearanceBlob")
+		return
+	}
+	if len(appearance.GetFeature()) != 0 {
+		t.Errorf("blob without feature should not provide appearance")
+	}
+	detection.SetFeature([]float32{1.0, 0.0})
+	err := tracker.MatchObjects([]*SimpleBlob{detection})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	// Detection without feature should not erase track's feature
+	err = tracker.MatchObjects([]*SimpleBlob{NewSimpleBlob(Rectangle{X: 11.0, Y: 10.0, Width: 20.0, Height: 20.0})})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	track := tracker.GetTracks()[0]
+	feature := track.GetFeature()
+	if len(feature) != 2 || feature[0] != 1.0 {
+		t.Errorf("incorrect track feature: %v, expected: %v", feature, []float32{1.0, 0.0})
+	}
+	next := NewSimpleBlob(Rectangle{X: 12.0, Y: 10.0, Width: 20.0, Height: 20.0})
+	next.SetFeature([]float32{0.0, 1.0})
+	err = tracker.MatchObjects([]*SimpleBlob{next})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if feature = track.GetFeature(); feature[1] != 1.0 {
+		t.Errorf("incorrect track feature after update: %v, expected: %v", feature, []float32{0.0, 1.0})
+	}
+}
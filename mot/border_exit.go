@@ -0,0 +1,35 @@
+package mot
+
+// SetBorderExit enables immediate termination of tracks which are leaving the frame: when unmatched track's predicted center
+// is outside of given bounds and the track moves outwards, the track is removed without waiting for maxNoMatch frames.
+// So it can't capture another object entering the frame at the same place. Rectangle with zero size disables the check
+func (tracker *SimpleTracker) SetBorderExit(bounds Rectangle) {
+	tracker.frameBounds = bounds
+}
+
+// GetBorderExit returns frame bounds which are used for border-exit termination
+func (tracker *SimpleTracker) GetBorderExit() Rectangle {
+	return tracker.frameBounds
+}
+
+// exitedFrame checks if track's predicted center has left the frame bounds with outbound velocity
+func (tracker *SimpleTracker) exitedFrame(object *SimpleBlob) bool {
+	bounds := tracker.frameBounds
+	if bounds.Width <= 0 || bounds.Height <= 0 {
+		return false
+	}
+	predicted := object.predictedNextPosition
+	vx := predicted.X - object.currentCenter.X
+	vy := predicted.Y - object.currentCenter.Y
+	switch {
+	case predicted.X < bounds.X && vx < 0:
+		return true
+	case predicted.X > bounds.X+bounds.Width && vx > 0:
+		return true
+	case predicted.Y < bounds.Y && vy < 0:
+		return true
+	case predicted.Y > bounds.Y+bounds.Height && vy > 0:
+		return true
+	}
+	return false
+}
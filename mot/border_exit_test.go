@@ -0,0 +1,39 @@
+package mot
+
+import (
+	"testing"
+)
+
+func TestBorderExit(t *testing.T) {
+	dt := 1.0
+	bounds := Rectangle{X: 0.0, Y: 0.0, Width: 200.0, Height: 200.0}
+	for _, enabled := range []bool{false, true} {
+		tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10))
+		if enabled {
+			tracker.SetBorderExit(bounds)
+		}
+		// Object moves right by 10 pixels per frame towards the right border
+		var blob *SimpleBlob
+		for frame := 0; frame < 10; frame++ {
+			newBlob := NewSimpleBlobWithTime(Rectangle{X: 100.0 + 10.0*float64(frame), Y: 100.0, Width: 4.0, Height: 4.0}, dt)
+			if frame == 0 {
+				blob = newBlob
+			}
+			err := tracker.MatchObjects([]*SimpleBlob{newBlob})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		for frame := 0; frame < 2; frame++ {
+			err := tracker.MatchObjects([]*SimpleBlob{})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		if _, ok := tracker.Objects[blob.GetID()]; ok == enabled {
+			t.Errorf("incorrect presence of track which left the frame (enabled=%v): %v, expected: %v", enabled, ok, !enabled)
+		}
+	}
+}
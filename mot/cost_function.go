@@ -0,0 +1,64 @@
+package mot
+
+import (
+	"math"
+
+	"github.com/LdDl/mot-go/mot/metrics"
+)
+
+// CostFunction returns association cost between existing track and new detection (lower is better).
+// Returning false means that the pair must not be matched
+type CostFunction func(track *SimpleBlob, detection *SimpleBlob) (float64, bool)
+
+// SetCostFunction replaces center distance with the given cost function (distance mode, threshold and appearance gate are ignored then,
+// so the function is responsible for gating). Nil restores the default behaviour
+func (tracker *SimpleTracker) SetCostFunction(fn CostFunction) {
+	tracker.costFunction = fn
+}
+
+// MahalanobisDistance returns squared Mahalanobis distance between the given point and blob's predicted center
+// according to uncertainty of blob's Kalman filter. Returns false if the uncertainty can't be evaluated
+func (blob *SimpleBlob) MahalanobisDistance(pt Point) (float64, bool) {
+	kf := blob.tracker
+	// Innovation covariance S = H*P*H^T + R, where H selects position from the state
+	sxx := kf.p[0] + kf.r[0]
+	sxy := kf.p[1] + kf.r[1]
+	syy := kf.p[5] + kf.r[3]
+	distance, err := metrics.SquaredMahalanobis2D(pt.X-blob.predictedNextPosition.X, pt.Y-blob.predictedNextPosition.Y, sxx, sxy, syy)
+	if err != nil {
+		return 0, false
+	}
+	return distance, true
+}
+
+// GatedAppearanceCost returns DeepSORT-style cost function: pairs whose squared Mahalanobis distance exceeds chi2Threshold
+// (e.g. 5.9915 for 2 degrees of freedom, see metrics.Chi2Threshold95) or whose cosine distance exceeds maxCosineDistance are rejected,
+// the remaining ones are ranked by cosine distance. Pairs without appearance features are ranked by Mahalanobis distance scaled to [0; 1]
+func GatedAppearanceCost(chi2Threshold, maxCosineDistance float64) CostFunction {
+	return func(track *SimpleBlob, detection *SimpleBlob) (float64, bool) {
+		mahalanobis, ok := track.MahalanobisDistance(detection.currentCenter)
+		if !ok || mahalanobis > chi2Threshold {
+			return 0, false
+		}
+		if len(detection.feature) == 0 {
+			return mahalanobis / chi2Threshold, true
+		}
+		cosine, ok := track.AppearanceDistance(detection.feature)
+		if !ok {
+			return mahalanobis / chi2Threshold, true
+		}
+		if cosine > maxCosineDistance {
+			return 0, false
+		}
+		return cosine, true
+	}
+}
+
+// customCost evaluates tracker's cost function. Rejected pairs get infinite cost
+func (tracker *SimpleTracker) customCost(newObject *SimpleBlob, object *SimpleBlob) float64 {
+	cost, ok := tracker.costFunction(object, newObject)
+	if !ok || math.IsNaN(cost) {
+		return math.MaxFloat64
+	}
+	return cost
+}
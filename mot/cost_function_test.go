@@ -0,0 +1,57 @@
+package mot
+
+import (
+	"testing"
+
+	"github.com/LdDl/mot-go/mot/metrics"
+)
+
+func TestGatedAppearanceCost(t *testing.T) {
+	chi2, _ := metrics.Chi2Threshold95(2)
+	tracker := NewSimpleTracker(WithMaxNoMatch(10), WithCostFunction(GatedAppearanceCost(chi2, 0.2)))
+	newBlob := func(x float64, feature []float32) *SimpleBlob {
+		blob := NewSimpleBlob(Rectangle{X: x, Y: 10.0, Width: 20.0, Height: 40.0})
+		blob.SetFeature(feature)
+		return blob
+	}
+	result, err := tracker.MatchObjectsWithResult([]*SimpleBlob{newBlob(10.0, []float32{1, 0, 0})})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	trackID := result.Created[0].TrackID
+	// Close detection with different appearance is rejected by appearance, the one with similar appearance is matched
+	result, err = tracker.MatchObjectsWithResult([]*SimpleBlob{
+		newBlob(11.0, []float32{0, 1, 0}),
+		newBlob(12.0, []float32{0.95, 0.05, 0}),
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result.Matched) != 1 || result.Matched[0].TrackID != trackID || result.Matched[0].DetectionIndex != 1 {
+		t.Errorf("incorrect matches: %v, expected detection 1 to be matched with %s", result.Matched, trackID)
+	}
+	// Similar detection which is too far is rejected by Mahalanobis gate
+	result, err = tracker.MatchObjectsWithResult([]*SimpleBlob{newBlob(500.0, []float32{1, 0, 0})})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result.Matched) != 0 {
+		t.Errorf("incorrect matches for far detection: %v, expected none", result.Matched)
+	}
+}
+
+func TestMahalanobisDistance(t *testing.T) {
+	blob := NewSimpleBlob(Rectangle{X: 10.0, Y: 10.0, Width: 20.0, Height: 40.0})
+	blob.PredictNextPosition()
+	near, ok := blob.MahalanobisDistance(blob.GetPredictedCenter())
+	if !ok || near != 0 {
+		t.Errorf("incorrect Mahalanobis distance to predicted center: %f, expected: %f", near, 0.0)
+	}
+	far, ok := blob.MahalanobisDistance(Point{X: blob.GetPredictedCenter().X + 10.0, Y: blob.GetPredictedCenter().Y})
+	if !ok || far <= near {
+		t.Errorf("incorrect Mahalanobis distance to far point: %f, expected more than: %f", far, near)
+	}
+}
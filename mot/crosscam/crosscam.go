@@ -0,0 +1,169 @@
+// Package crosscam matches track identities across multiple trackers (cameras) by appearance
+// and time-of-transit constraints, giving single global identifier to each physical object
+package crosscam
+
+import (
+	"sync"
+	"time"
+
+	"github.com/LdDl/mot-go/mot"
+	"github.com/google/uuid"
+)
+
+// Transit is allowed movement of objects between two cameras
+type Transit struct {
+	From string
+	To   string
+	// Range of time between leaving the first camera and appearing in the second one
+	MinTime time.Duration
+	MaxTime time.Duration
+}
+
+// cameraTrack identifies track of single camera
+type cameraTrack struct {
+	camera  string
+	trackID uuid.UUID
+}
+
+// exitRecord is track which has left camera's field of view and could appear in another camera
+type exitRecord struct {
+	camera   string
+	globalID uuid.UUID
+	features [][]float32
+	exitedAt time.Time
+}
+
+// Matcher assigns global identifiers to tracks of multiple cameras. It is safe for concurrent use.
+// Call Observe when track is created (e.g. from SimpleTracker.SetOnTrackCreated) and Exit when it is removed
+// (e.g. from SimpleTracker.SetOnTrackRemoved)
+type Matcher struct {
+	mu                sync.Mutex
+	maxCosineDistance float64
+	transits          map[[2]string]Transit
+	maxTransit        time.Duration
+	global            map[cameraTrack]uuid.UUID
+	exits             []exitRecord
+}
+
+// NewMatcher creates matcher which links tracks whose appearance is within maxCosineDistance
+func NewMatcher(maxCosineDistance float64) *Matcher {
+	return &Matcher{
+		maxCosineDistance: maxCosineDistance,
+		transits:          make(map[[2]string]Transit),
+		global:            make(map[cameraTrack]uuid.UUID),
+		exits:             make([]exitRecord, 0),
+	}
+}
+
+// AddTransit allows objects to move between cameras. Tracks are linked only along allowed transits
+// (add transit from camera to itself to handle re-entering of the same camera)
+func (matcher *Matcher) AddTransit(transit Transit) {
+	matcher.mu.Lock()
+	defer matcher.mu.Unlock()
+	matcher.transits[[2]string{transit.From, transit.To}] = transit
+	if transit.MaxTime > matcher.maxTransit {
+		matcher.maxTransit = transit.MaxTime
+	}
+}
+
+// Observe returns global identifier of the track which has been seen by the camera at the given time.
+// New track is linked to the most similar track which has left some camera recently enough (according to transits);
+// otherwise it gets new global identifier
+func (matcher *Matcher) Observe(camera string, track *mot.SimpleBlob, at time.Time) uuid.UUID {
+	matcher.mu.Lock()
+	defer matcher.mu.Unlock()
+	key := cameraTrack{camera: camera, trackID: track.GetID()}
+	if globalID, ok := matcher.global[key]; ok {
+		return globalID
+	}
+	matcher.expire(at)
+	bestIdx := -1
+	bestDistance := 0.0
+	for i, record := range matcher.exits {
+		transit, ok := matcher.transits[[2]string{record.camera, camera}]
+		if !ok {
+			continue
+		}
+		elapsed := at.Sub(record.exitedAt)
+		if elapsed < transit.MinTime || elapsed > transit.MaxTime {
+			continue
+		}
+		distance, ok := appearanceDistance(track, record.features)
+		if !ok || distance > matcher.maxCosineDistance {
+			continue
+		}
+		if bestIdx < 0 || distance < bestDistance {
+			bestIdx, bestDistance = i, distance
+		}
+	}
+	globalID := uuid.New()
+	if bestIdx >= 0 {
+		globalID = matcher.exits[bestIdx].globalID
+		matcher.exits = append(matcher.exits[:bestIdx], matcher.exits[bestIdx+1:]...)
+	}
+	matcher.global[key] = globalID
+	return globalID
+}
+
+// Exit registers that track has left camera's field of view at the given time, so it could be linked with tracks of other cameras
+func (matcher *Matcher) Exit(camera string, track *mot.SimpleBlob, at time.Time) {
+	matcher.mu.Lock()
+	defer matcher.mu.Unlock()
+	key := cameraTrack{camera: camera, trackID: track.GetID()}
+	globalID, ok := matcher.global[key]
+	if !ok {
+		globalID = uuid.New()
+	}
+	delete(matcher.global, key)
+	features := append([][]float32{}, track.GetGallery()...)
+	if len(features) == 0 && len(track.GetFeature()) > 0 {
+		features = append(features, track.GetFeature())
+	}
+	if len(features) == 0 {
+		return
+	}
+	matcher.exits = append(matcher.exits, exitRecord{
+		camera:   camera,
+		globalID: globalID,
+		features: features,
+		exitedAt: at,
+	})
+}
+
+// GlobalID returns global identifier of the track which is currently observed by the camera
+func (matcher *Matcher) GlobalID(camera string, trackID uuid.UUID) (uuid.UUID, bool) {
+	matcher.mu.Lock()
+	defer matcher.mu.Unlock()
+	globalID, ok := matcher.global[cameraTrack{camera: camera, trackID: trackID}]
+	return globalID, ok
+}
+
+// expire drops exit records which can't be linked anymore
+func (matcher *Matcher) expire(now time.Time) {
+	n := 0
+	for _, record := range matcher.exits {
+		if now.Sub(record.exitedAt) > matcher.maxTransit {
+			continue
+		}
+		matcher.exits[n] = record
+		n++
+	}
+	for i := n; i < len(matcher.exits); i++ {
+		matcher.exits[i] = exitRecord{}
+	}
+	matcher.exits = matcher.exits[:n]
+}
+
+// appearanceDistance returns the minimum cosine distance between track's appearance and given features
+func appearanceDistance(track *mot.SimpleBlob, features [][]float32) (float64, bool) {
+	bestDistance := 0.0
+	found := false
+	for _, feature := range features {
+		distance, ok := track.AppearanceDistance(feature)
+		if ok && (!found || distance < bestDistance) {
+			bestDistance = distance
+			found = true
+		}
+	}
+	return bestDistance, found
+}
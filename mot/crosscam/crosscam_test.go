@@ -0,0 +1,49 @@
+package crosscam
+
+import (
+	"testing"
+	"time"
+
+	"github.com/LdDl/mot-go/mot"
+)
+
+func newTrack(feature []float32) *mot.SimpleBlob {
+	track := mot.NewSimpleBlob(mot.Rectangle{X: 10.0, Y: 10.0, Width: 20.0, Height: 40.0})
+	track.SetFeature(feature)
+	return track
+}
+
+func TestMatcher(t *testing.T) {
+	matcher := NewMatcher(0.2)
+	matcher.AddTransit(Transit{From: "entrance", To: "hall", MinTime: 5 * time.Second, MaxTime: 30 * time.Second})
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	person := newTrack([]float32{0.2, 0.9, 0.1})
+	personID := matcher.Observe("entrance", person, start)
+	if again := matcher.Observe("entrance", person, start.Add(time.Second)); again != personID {
+		t.Errorf("incorrect global id of the same track: %s, expected: %s", again, personID)
+	}
+	matcher.Exit("entrance", person, start.Add(2*time.Second))
+	if _, ok := matcher.GlobalID("entrance", person.GetID()); ok {
+		t.Errorf("track which has left the camera should not have global id")
+	}
+
+	// Too early: nobody could get from entrance to hall so fast
+	early := newTrack([]float32{0.2, 0.9, 0.1})
+	if earlyID := matcher.Observe("hall", early, start.Add(3*time.Second)); earlyID == personID {
+		t.Errorf("track should not be linked before min transit time")
+	}
+	stranger := newTrack([]float32{0.9, 0.1, 0.2})
+	if strangerID := matcher.Observe("hall", stranger, start.Add(10*time.Second)); strangerID == personID {
+		t.Errorf("track with different appearance should not be linked")
+	}
+	samePerson := newTrack([]float32{0.25, 0.9, 0.1})
+	if sameID := matcher.Observe("hall", samePerson, start.Add(12*time.Second)); sameID != personID {
+		t.Errorf("incorrect global id after transit: %s, expected: %s", sameID, personID)
+	}
+	// Exit record is consumed, so the next look-alike gets new identifier
+	lookAlike := newTrack([]float32{0.2, 0.9, 0.1})
+	if lookAlikeID := matcher.Observe("hall", lookAlike, start.Add(13*time.Second)); lookAlikeID == personID {
+		t.Errorf("exit record should be linked only once")
+	}
+}
@@ -0,0 +1,50 @@
+package mot
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// DebugStage holds cost matrix computed during single association stage and the assignment which has been chosen
+type DebugStage struct {
+	// Name of the stage
+	Name string
+	// Identifiers of existing tracks (columns of cost matrix)
+	TrackIDs []uuid.UUID
+	// Cost matrix: rows are detections (in order of input slice), columns are tracks.
+	// Pairs which have been pruned by spatial index are filled with +Inf
+	Costs [][]float64
+	// Chosen assignment: index of column for each detection or -1 if detection has not been assigned to existing track
+	Assignment []int
+	// Column index for each track identifier
+	columns map[uuid.UUID]int
+}
+
+// DebugInfo is the set of association stages captured during single MatchObjects call
+type DebugInfo struct {
+	Stages []*DebugStage
+}
+
+func newDebugStage(name string, trackIDs []uuid.UUID, detectionsNum int) *DebugStage {
+	costs := make([][]float64, detectionsNum)
+	assignment := make([]int, detectionsNum)
+	columns := make(map[uuid.UUID]int, len(trackIDs))
+	for j, trackID := range trackIDs {
+		columns[trackID] = j
+	}
+	for i := range costs {
+		costs[i] = make([]float64, len(trackIDs))
+		for j := range costs[i] {
+			costs[i][j] = math.Inf(1)
+		}
+		assignment[i] = -1
+	}
+	return &DebugStage{
+		Name:       name,
+		TrackIDs:   trackIDs,
+		Costs:      costs,
+		Assignment: assignment,
+		columns:    columns,
+	}
+}
@@ -0,0 +1,111 @@
+package mot
+
+// detectionFilter rejects detections before association, so they neither spawn tracks nor steal matches
+type detectionFilter struct {
+	minBoxArea float64
+	minWidth   float64
+	minHeight  float64
+	// Acceptable range of width/height ratio. Zero disables corresponding bound
+	minAspectRatio float64
+	maxAspectRatio float64
+	// IoU threshold for non-maximum suppression. Zero disables suppression
+	nmsThreshold float64
+	// Frame size which bounding boxes are clamped to. Zero disables clamping
+	frameWidth  float64
+	frameHeight float64
+}
+
+// clampEnabled checks if bounding boxes should be clamped to the frame
+func (filter *detectionFilter) clampEnabled() bool {
+	return filter.frameWidth > 0 && filter.frameHeight > 0
+}
+
+// accepts checks whether detection passes the filter
+func (filter *detectionFilter) accepts(blob *SimpleBlob) bool {
+	bbox := blob.currentBBox
+	if bbox.Width < filter.minWidth || bbox.Height < filter.minHeight {
+		return false
+	}
+	if bbox.Area() < filter.minBoxArea {
+		return false
+	}
+	if filter.minAspectRatio > 0 || filter.maxAspectRatio > 0 {
+		if bbox.Height <= 0 {
+			return false
+		}
+		aspectRatio := bbox.Width / bbox.Height
+		if aspectRatio < filter.minAspectRatio {
+			return false
+		}
+		if filter.maxAspectRatio > 0 && aspectRatio > filter.maxAspectRatio {
+			return false
+		}
+	}
+	return true
+}
+
+// SetMinBoxArea sets min area of detection's bounding box. Smaller detections are ignored. Zero disables the check
+func (tracker *SimpleTracker) SetMinBoxArea(minBoxArea float64) {
+	tracker.filter.minBoxArea = minBoxArea
+}
+
+// GetMinBoxArea returns min area of detection's bounding box
+func (tracker *SimpleTracker) GetMinBoxArea() float64 {
+	return tracker.filter.minBoxArea
+}
+
+// SetMinBoxSize sets min width and height of detection's bounding box. Smaller detections are ignored. Zero disables the check
+func (tracker *SimpleTracker) SetMinBoxSize(minWidth, minHeight float64) {
+	tracker.filter.minWidth = minWidth
+	tracker.filter.minHeight = minHeight
+}
+
+// GetMinBoxSize returns min width and height of detection's bounding box
+func (tracker *SimpleTracker) GetMinBoxSize() (float64, float64) {
+	return tracker.filter.minWidth, tracker.filter.minHeight
+}
+
+// SetAspectRatioRange sets acceptable range of width/height ratio of detection's bounding box (e.g. 0.1 and 10.0).
+// Detections outside of the range are ignored. Zero disables corresponding bound
+func (tracker *SimpleTracker) SetAspectRatioRange(minAspectRatio, maxAspectRatio float64) {
+	tracker.filter.minAspectRatio = minAspectRatio
+	tracker.filter.maxAspectRatio = maxAspectRatio
+}
+
+// GetAspectRatioRange returns acceptable range of width/height ratio of detection's bounding box
+func (tracker *SimpleTracker) GetAspectRatioRange() (float64, float64) {
+	return tracker.filter.minAspectRatio, tracker.filter.maxAspectRatio
+}
+
+// SetClampToFrame enables clamping of detections and predicted bounding boxes to the frame [0; frameWidth] x [0; frameHeight],
+// so boxes partially lying outside of the image do not distort area and IoU computations.
+// Only bounding boxes are clamped: centers (which are tracked by Kalman filter) stay intact. Zero size disables clamping
+func (tracker *SimpleTracker) SetClampToFrame(frameWidth, frameHeight float64) {
+	tracker.filter.frameWidth = frameWidth
+	tracker.filter.frameHeight = frameHeight
+}
+
+// GetClampToFrame returns frame size which bounding boxes are clamped to
+func (tracker *SimpleTracker) GetClampToFrame() (float64, float64) {
+	return tracker.filter.frameWidth, tracker.filter.frameHeight
+}
+
+// filterDetections marks detections which do not pass the filter
+func (tracker *SimpleTracker) filterDetections(newObjects []*SimpleBlob, result *MatchResult) {
+	rejected := tracker.buffers.rejected
+	for i, newObject := range newObjects {
+		if tracker.filter.clampEnabled() {
+			newObject.currentBBox = Clamp(newObject.currentBBox, tracker.filter.frameWidth, tracker.filter.frameHeight)
+		}
+		if tracker.filter.accepts(newObject) {
+			continue
+		}
+		rejected[i] = true
+		if result != nil {
+			result.Rejected = append(result.Rejected, i)
+		}
+	}
+	if tracker.filter.nmsThreshold > 0 {
+		tracker.suppressDetections(newObjects, result)
+	}
+}
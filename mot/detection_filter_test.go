@@ -0,0 +1,69 @@
+package mot
+
+import (
+	"testing"
+)
+
+func TestMinBoxFilter(t *testing.T) {
+	for _, debug := range []bool{false, true} {
+		tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10), WithMinBoxArea(50.0), WithMinBoxSize(3.0, 3.0))
+		tracker.SetDebug(debug)
+		blob := NewSimpleBlob(NewRect(10.0, 10.0, 20.0, 20.0))
+		err := tracker.MatchObjects([]*SimpleBlob{blob})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		tiny := NewSimpleBlob(NewRect(19.0, 19.0, 2.0, 2.0))
+		thin := NewSimpleBlob(NewRect(200.0, 10.0, 1.0, 100.0))
+		result, err := tracker.MatchObjectsWithResult([]*SimpleBlob{tiny, thin})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(result.Rejected) != 2 || result.Rejected[0] != 0 || result.Rejected[1] != 1 {
+			t.Errorf("incorrect rejected detections (debug=%v): %v, expected: %v", debug, result.Rejected, []int{0, 1})
+		}
+		if len(result.Matched) != 0 || len(result.Created) != 0 {
+			t.Errorf("rejected detections should not be associated (debug=%v): %d matched and %d created, expected: %d and %d", debug, len(result.Matched), len(result.Created), 0, 0)
+		}
+		if len(tracker.Objects) != 1 {
+			t.Errorf("incorrect number of tracks (debug=%v): %d, expected: %d", debug, len(tracker.Objects), 1)
+		}
+	}
+}
+
+func TestAspectRatioFilter(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10), WithAspectRatioRange(0.2, 5.0))
+	normal := NewSimpleBlob(Rectangle{X: 10.0, Y: 10.0, Width: 20.0, Height: 40.0})
+	wide := NewSimpleBlob(Rectangle{X: 100.0, Y: 10.0, Width: 100.0, Height: 10.0})
+	tall := NewSimpleBlob(Rectangle{X: 300.0, Y: 10.0, Width: 10.0, Height: 100.0})
+	result, err := tracker.MatchObjectsWithResult([]*SimpleBlob{normal, wide, tall})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result.Rejected) != 2 || result.Rejected[0] != 1 || result.Rejected[1] != 2 {
+		t.Errorf("incorrect rejected detections: %v, expected: %v", result.Rejected, []int{1, 2})
+	}
+	if len(result.Created) != 1 || result.Created[0].DetectionIndex != 0 {
+		t.Errorf("incorrect created tracks: %v, expected single track for detection %d", result.Created, 0)
+	}
+}
+
+func TestClampToFrame(t *testing.T) {
+	// Without clamping the box passes area filter since most of it lies outside of the frame
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10), WithMinBoxArea(200.0), WithClampToFrame(640.0, 480.0))
+	blob := NewSimpleBlob(Rectangle{X: -25.0, Y: 10.0, Width: 30.0, Height: 30.0})
+	result, err := tracker.MatchObjectsWithResult([]*SimpleBlob{blob})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result.Rejected) != 1 {
+		t.Errorf("incorrect number of rejected detections: %d, expected: %d", len(result.Rejected), 1)
+	}
+	if blob.GetBBox() != (Rectangle{X: 0.0, Y: 10.0, Width: 5.0, Height: 30.0}) {
+		t.Errorf("incorrect clamped bounding box: %v, expected: %v", blob.GetBBox(), Rectangle{X: 0.0, Y: 10.0, Width: 5.0, Height: 30.0})
+	}
+}
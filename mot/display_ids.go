@@ -0,0 +1,120 @@
+package mot
+
+import (
+	"sort"
+	"sync"
+)
+
+// DisplayIDPolicy defines how compact display identifiers are assigned to tracks
+type DisplayIDPolicy uint16
+
+const (
+	// DisplayIDSequential assigns monotonically increasing identifiers which are never reused
+	DisplayIDSequential = DisplayIDPolicy(iota)
+	// DisplayIDRecycle assigns the smallest identifier which has been released by removed track (if any)
+	DisplayIDRecycle
+)
+
+// displayIDs allocates compact display identifiers (starting from 1). It is shared between tiles of TiledTracker
+type displayIDs struct {
+	mu     sync.Mutex
+	policy DisplayIDPolicy
+	next   int
+	// Released identifiers in ascending order (DisplayIDRecycle only)
+	free []int
+}
+
+func newDisplayIDs(policy DisplayIDPolicy) *displayIDs {
+	return &displayIDs{
+		policy: policy,
+		next:   1,
+	}
+}
+
+// acquire returns identifier for the new track
+func (ids *displayIDs) acquire() int {
+	ids.mu.Lock()
+	defer ids.mu.Unlock()
+	if len(ids.free) > 0 {
+		id := ids.free[0]
+		ids.free = ids.free[1:]
+		return id
+	}
+	id := ids.next
+	ids.next++
+	return id
+}
+
+// release returns identifier of removed track to the pool
+func (ids *displayIDs) release(id int) {
+	if id <= 0 || ids.policy != DisplayIDRecycle {
+		return
+	}
+	ids.mu.Lock()
+	defer ids.mu.Unlock()
+	idx := sort.SearchInts(ids.free, id)
+	ids.free = append(ids.free, 0)
+	copy(ids.free[idx+1:], ids.free[idx:])
+	ids.free[idx] = id
+}
+
+// reset makes pool consistent with identifiers which are in use (e.g. after restoring snapshot)
+func (ids *displayIDs) reset(next int, used []int) {
+	ids.mu.Lock()
+	defer ids.mu.Unlock()
+	if next < 1 {
+		next = 1
+	}
+	inUse := make(map[int]struct{}, len(used))
+	for _, id := range used {
+		inUse[id] = struct{}{}
+		if id >= next {
+			next = id + 1
+		}
+	}
+	ids.next = next
+	ids.free = ids.free[:0]
+	if ids.policy != DisplayIDRecycle {
+		return
+	}
+	for id := 1; id < next; id++ {
+		if _, ok := inUse[id]; !ok {
+			ids.free = append(ids.free, id)
+		}
+	}
+}
+
+// SetDisplayIDs enables assigning of compact display identifiers (1, 2, 3...) to tracks when they become visible.
+// They are suitable for on-screen labels, while UUIDs stay the primary identifiers. Existing tracks do not get display identifiers
+func (tracker *SimpleTracker) SetDisplayIDs(policy DisplayIDPolicy) {
+	tracker.displayIDs = newDisplayIDs(policy)
+}
+
+// DisableDisplayIDs stops assigning of display identifiers. Tracks keep already assigned ones
+func (tracker *SimpleTracker) DisableDisplayIDs() {
+	tracker.displayIDs = nil
+}
+
+// SetDisplayIDs enables display identifiers which are unique across all tiles. See SimpleTracker.SetDisplayIDs
+func (tracker *TiledTracker) SetDisplayIDs(policy DisplayIDPolicy) {
+	ids := newDisplayIDs(policy)
+	for _, tile := range tracker.tiles {
+		tile.displayIDs = ids
+	}
+}
+
+// assignDisplayID gives display identifier to the track which becomes visible
+func (tracker *SimpleTracker) assignDisplayID(blob *SimpleBlob) {
+	if tracker.displayIDs == nil || blob.displayID != 0 {
+		return
+	}
+	blob.displayID = tracker.displayIDs.acquire()
+}
+
+// releaseDisplayID returns display identifier of the removed track
+func (tracker *SimpleTracker) releaseDisplayID(blob *SimpleBlob) {
+	if tracker.displayIDs == nil {
+		return
+	}
+	tracker.displayIDs.release(blob.displayID)
+}
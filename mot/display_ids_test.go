@@ -0,0 +1,78 @@
+package mot
+
+import (
+	"testing"
+)
+
+func TestDisplayIDsAllocation(t *testing.T) {
+	sequential := newDisplayIDs(DisplayIDSequential)
+	recycle := newDisplayIDs(DisplayIDRecycle)
+	for i := 1; i <= 3; i++ {
+		if id := sequential.acquire(); id != i {
+			t.Errorf("incorrect sequential display id: %d, expected: %d", id, i)
+		}
+		if id := recycle.acquire(); id != i {
+			t.Errorf("incorrect recycled display id: %d, expected: %d", id, i)
+		}
+	}
+	sequential.release(2)
+	recycle.release(3)
+	recycle.release(2)
+	if id := sequential.acquire(); id != 4 {
+		t.Errorf("incorrect sequential display id after release: %d, expected: %d", id, 4)
+	}
+	if id := recycle.acquire(); id != 2 {
+		t.Errorf("incorrect recycled display id after release: %d, expected: %d", id, 2)
+	}
+	recycle.reset(0, []int{1, 5})
+	if id := recycle.acquire(); id != 2 {
+		t.Errorf("incorrect recycled display id after reset: %d, expected: %d", id, 2)
+	}
+}
+
+func TestTrackerDisplayIDs(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(1), WithDisplayIDs(DisplayIDRecycle))
+	events := make([]Event, 0)
+	tracker.OnEvent(func(event Event) {
+		events = append(events, event)
+	})
+	err := tracker.MatchObjects([]*SimpleBlob{
+		NewSimpleBlob(Rectangle{X: 10.0, Y: 10.0, Width: 20.0, Height: 20.0}),
+		NewSimpleBlob(Rectangle{X: 200.0, Y: 10.0, Width: 20.0, Height: 20.0}),
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	tracks := tracker.GetTracks()
+	if len(tracks) != 2 || tracks[0].GetDisplayID() != 1 || tracks[1].GetDisplayID() != 2 {
+		t.Errorf("incorrect display ids of tracks: %v", tracks)
+		return
+	}
+	if events[0].DisplayID != 1 || events[1].DisplayID != 2 {
+		t.Errorf("incorrect display ids of events: %d and %d, expected: %d and %d", events[0].DisplayID, events[1].DisplayID, 1, 2)
+	}
+	// The first track disappears, so its display id should be reused
+	for i := 0; i < 3; i++ {
+		err = tracker.MatchObjects([]*SimpleBlob{NewSimpleBlob(Rectangle{X: 200.0, Y: 10.0, Width: 20.0, Height: 20.0})})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	err = tracker.MatchObjects([]*SimpleBlob{
+		NewSimpleBlob(Rectangle{X: 200.0, Y: 10.0, Width: 20.0, Height: 20.0}),
+		NewSimpleBlob(Rectangle{X: 400.0, Y: 10.0, Width: 20.0, Height: 20.0}),
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	tracks = tracker.GetTracks()
+	if len(tracks) != 2 || tracks[0].GetDisplayID() != 2 || tracks[1].GetDisplayID() != 1 {
+		t.Errorf("incorrect display ids after recycling: %d tracks", len(tracks))
+		for _, track := range tracks {
+			t.Errorf("track %s has display id %d", track.GetID(), track.GetDisplayID())
+		}
+	}
+}
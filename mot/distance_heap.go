@@ -1,13 +1,41 @@
 package mot
 
-import "github.com/google/uuid"
+import (
+	"sync"
+
+	"github.com/google/uuid"
+)
 
 type distanceBlob struct {
 	underlying *SimpleBlob
+	index      int
 	id         uuid.UUID
 	distance   float64
 }
 
+// distanceBlobPool reduces GC churn when a lot of detections are processed per second
+var distanceBlobPool = sync.Pool{
+	New: func() interface{} {
+		return &distanceBlob{}
+	},
+}
+
+// acquireDistanceBlob takes heap item from the pool
+func acquireDistanceBlob(underlying *SimpleBlob, index int, id uuid.UUID, distance float64) *distanceBlob {
+	item := distanceBlobPool.Get().(*distanceBlob)
+	item.underlying = underlying
+	item.index = index
+	item.id = id
+	item.distance = distance
+	return item
+}
+
+// releaseDistanceBlob returns heap item to the pool. Item must not be used after release
+func releaseDistanceBlob(item *distanceBlob) {
+	item.underlying = nil
+	distanceBlobPool.Put(item)
+}
+
 /* Copied from container/heap - https://golang.org/pkg/container/heap/ */
 // Why make copy? Just want to avoid type conversion
 
@@ -0,0 +1,38 @@
+package mot
+
+// DistanceMode defines which centers of existing tracks are used to measure distance to new detections
+type DistanceMode uint16
+
+const (
+	// DistanceCurrent compares detection's center with the last known center of track
+	DistanceCurrent = DistanceMode(iota)
+	// DistancePredicted compares detection's center with center of track predicted by Kalman filter for the current frame
+	DistancePredicted
+	// DistanceMin takes the minimum of DistanceCurrent and DistancePredicted
+	DistanceMin
+	// DistanceAppearance ignores motion and compares appearance features only (see SimpleTracker.SetAppearanceOnly)
+	DistanceAppearance
+)
+
+// SetDistanceMode sets which centers of existing tracks are used during matching. Default is DistanceCurrent
+func (tracker *SimpleTracker) SetDistanceMode(mode DistanceMode) {
+	tracker.distanceMode = mode
+}
+
+// GetDistanceMode returns which centers of existing tracks are used during matching
+func (tracker *SimpleTracker) GetDistanceMode() DistanceMode {
+	return tracker.distanceMode
+}
+
+// indexTrack inserts track into spatial index at the points which are used for distance evaluation
+func (tracker *SimpleTracker) indexTrack(object *SimpleBlob) {
+	switch tracker.distanceMode {
+	case DistancePredicted:
+		tracker.index.insert(object.id, object, object.predictedNextPosition)
+	case DistanceMin:
+		tracker.index.insert(object.id, object, object.currentCenter)
+		tracker.index.insert(object.id, object, object.predictedNextPosition)
+	default:
+		tracker.index.insert(object.id, object, object.currentCenter)
+	}
+}
@@ -0,0 +1,61 @@
+package mot
+
+import (
+	"testing"
+)
+
+func TestDistanceModes(t *testing.T) {
+	// Object moves by 10 pixels per frame: after a few frames predicted center is far ahead of the last known one
+	dt := 1.0
+	modes := []DistanceMode{DistanceCurrent, DistancePredicted, DistanceMin}
+	indices := []SpatialIndex{SpatialIndexGrid, SpatialIndexKDTree, SpatialIndexNone}
+	for _, mode := range modes {
+		for _, indexType := range indices {
+			tracker := NewSimpleTracker(WithMinDistThreshold(12.0), WithMaxNoMatch(10), WithDistanceMode(mode), WithSpatialIndex(indexType))
+			// Static objects far from the moving one: there are enough tracks for spatial index to be queried instead of linear scan
+			statics := make([]Rectangle, smallFrameLimit+4)
+			for i := range statics {
+				statics[i] = Rectangle{X: 50.0 * float64(i), Y: 400.0, Width: 4.0, Height: 4.0}
+			}
+			withStatics := func(blobs ...*SimpleBlob) []*SimpleBlob {
+				for _, bbox := range statics {
+					blobs = append(blobs, NewSimpleBlobWithTime(bbox, dt))
+				}
+				return blobs
+			}
+			var trackID string
+			for frame := 0; frame < 6; frame++ {
+				blob := NewSimpleBlobWithTime(Rectangle{X: 100.0 + 10.0*float64(frame), Y: 100.0, Width: 4.0, Height: 4.0}, dt)
+				err := tracker.MatchObjects(withStatics(blob))
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if frame == 0 {
+					trackID = blob.GetID().String()
+				}
+			}
+			// Detection is lost for two frames and then appears again 30 pixels further
+			for frame := 0; frame < 2; frame++ {
+				err := tracker.MatchObjects(withStatics())
+				if err != nil {
+					t.Error(err)
+					return
+				}
+			}
+			reappeared := NewSimpleBlobWithTime(Rectangle{X: 180.0, Y: 100.0, Width: 4.0, Height: 4.0}, dt)
+			err := tracker.MatchObjects(withStatics(reappeared))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if indexType != SpatialIndexNone && (tracker.index == nil || !tracker.index.worthQuerying(tracker.gateThreshold(nil))) {
+				t.Errorf("spatial index should be queried (index=%d)", indexType)
+			}
+			matched := reappeared.GetID().String() == trackID
+			if matched != (mode != DistanceCurrent) {
+				t.Errorf("incorrect matching of reappeared object (mode=%d, index=%d): %v, expected: %v", mode, indexType, matched, mode != DistanceCurrent)
+			}
+		}
+	}
+}
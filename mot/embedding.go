@@ -0,0 +1,64 @@
+package mot
+
+import "math"
+
+// FeatureNormalization defines how appearance features of detections are normalized when they are passed to tracker
+type FeatureNormalization uint16
+
+const (
+	// FeatureNormalizationNone keeps features as is
+	FeatureNormalizationNone = FeatureNormalization(iota)
+	// FeatureNormalizationL2 scales features to unit euclidean norm
+	FeatureNormalizationL2
+)
+
+// NewFeature converts embedding of any floating-point type (e.g. output of model from another framework) to appearance feature.
+// Embeddings of any dimension are accepted; features of different dimensions are just never compared with each other
+func NewFeature[T Float](embedding []T) []float32 {
+	feature := make([]float32, len(embedding))
+	for i, value := range embedding {
+		feature[i] = float32(value)
+	}
+	return feature
+}
+
+// NormalizeL2 returns copy of feature scaled to unit euclidean norm. Feature with zero norm is copied as is
+func NormalizeL2(feature []float32) []float32 {
+	normalized := make([]float32, len(feature))
+	norm := 0.0
+	for _, value := range feature {
+		norm += float64(value) * float64(value)
+	}
+	if norm == 0 {
+		copy(normalized, feature)
+		return normalized
+	}
+	norm = math.Sqrt(norm)
+	for i, value := range feature {
+		normalized[i] = float32(float64(value) / norm)
+	}
+	return normalized
+}
+
+// SetFeatureNormalization sets normalization which is applied to appearance features of detections in MatchObjects.
+// Normalized feature is a copy, so slices passed to SetFeature are not modified. Default is FeatureNormalizationNone
+func (tracker *SimpleTracker) SetFeatureNormalization(normalization FeatureNormalization) {
+	tracker.featureNormalization = normalization
+}
+
+// GetFeatureNormalization returns normalization which is applied to appearance features of detections
+func (tracker *SimpleTracker) GetFeatureNormalization() FeatureNormalization {
+	return tracker.featureNormalization
+}
+
+// normalizeFeatures applies feature normalization to detections
+func (tracker *SimpleTracker) normalizeFeatures(newObjects []*SimpleBlob) {
+	if tracker.featureNormalization != FeatureNormalizationL2 {
+		return
+	}
+	for _, newObject := range newObjects {
+		if len(newObject.feature) > 0 {
+			newObject.feature = NormalizeL2(newObject.feature)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package mot
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewFeature(t *testing.T) {
+	feature := NewFeature([]float64{0.5, 1.5, -2})
+	if len(feature) != 3 || feature[0] != 0.5 || feature[2] != -2 {
+		t.Errorf("incorrect feature: %v, expected: %v", feature, []float32{0.5, 1.5, -2})
+	}
+	normalized := NormalizeL2([]float32{3, 4})
+	if math.Abs(float64(normalized[0])-0.6) > 1e-6 || math.Abs(float64(normalized[1])-0.8) > 1e-6 {
+		t.Errorf("incorrect normalized feature: %v, expected: %v", normalized, []float32{0.6, 0.8})
+	}
+	if zero := NormalizeL2([]float32{0, 0}); zero[0] != 0 || zero[1] != 0 {
+		t.Errorf("incorrect normalized zero feature: %v", zero)
+	}
+}
+
+func TestFeatureNormalization(t *testing.T) {
+	tracker := NewSimpleTracker(WithFeatureNormalization(FeatureNormalizationL2))
+	embedding := []float32{3, 4}
+	detection := NewSimpleBlob(Rectangle{X: 10.0, Y: 10.0, Width: 20.0, Height: 40.0})
+	detection.SetFeature(embedding)
+	// Detection with embedding of another dimension should not break matching
+	other := NewSimpleBlob(Rectangle{X: 100.0, Y: 10.0, Width: 20.0, Height: 40.0})
+	other.SetFeature(NewFeature([]float64{1, 2, 3, 4}))
+	err := tracker.MatchObjects([]*SimpleBlob{detection, other})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	feature := tracker.GetTracks()[0].GetFeature()
+	if math.Abs(float64(feature[0])-0.6) > 1e-6 || math.Abs(float64(feature[1])-0.8) > 1e-6 {
+		t.Errorf("incorrect normalized track feature: %v, expected: %v", feature, []float32{0.6, 0.8})
+	}
+	if embedding[0] != 3 {
+		t.Errorf("original embedding should not be modified: %v", embedding)
+	}
+}
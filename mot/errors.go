@@ -0,0 +1,57 @@
+package mot
+
+import (
+	"errors"
+)
+
+var (
+	// ErrLengthMismatch is returned when parallel input slices have different lengths
+	ErrLengthMismatch = errors.New("length mismatch")
+	// ErrUnknownTrack is returned when track with given identifier does not exist
+	ErrUnknownTrack = errors.New("unknown track")
+	// ErrDuplicateTrack is returned when track with given identifier exists already
+	ErrDuplicateTrack = errors.New("duplicate track")
+	// ErrInvalidBBox is returned when bounding box (or blob itself) is not valid: nil blob, NaN or infinite coordinates, negative size
+	ErrInvalidBBox = errors.New("invalid bounding box")
+	// ErrKalmanUpdate is returned when Kalman filter can't be updated with the new measurement
+	ErrKalmanUpdate = errors.New("kalman filter update failed")
+	// ErrInvalidGeometry is returned when geometry (e.g. polygon) can't be built from the given points
+	ErrInvalidGeometry = errors.New("invalid geometry")
+	// ErrInvalidSplit is returned when track can't be split at the given frame
+	ErrInvalidSplit = errors.New("invalid split")
+	// ErrSuspended is returned when frame is passed to tracker which has been suspended
+	ErrSuspended = errors.New("tracker is suspended")
+	// ErrInvalidSnapshot is returned when tracker's state (or feature bank) can't be restored from the given data
+	ErrInvalidSnapshot = errors.New("invalid snapshot")
+	// ErrInvalidCameraModel is returned when camera intrinsics can't be used to map points (e.g. zero focal length)
+	ErrInvalidCameraModel = errors.New("invalid camera model")
+	// ErrLateFrame is returned when frame arrives after frames with later timestamps have been processed already
+	ErrLateFrame = errors.New("late frame")
+)
+
+// sentinelError attaches sentinel error to the actual cause, so both could be checked via errors.Is
+type sentinelError struct {
+	sentinel error
+	cause    error
+}
+
+func withSentinel(sentinel error, cause error) error {
+	return &sentinelError{
+		sentinel: sentinel,
+		cause:    cause,
+	}
+}
+
+func (err *sentinelError) Error() string {
+	return err.sentinel.Error() + ": " + err.cause.Error()
+}
+
+// Is reports whether target is the attached sentinel error
+func (err *sentinelError) Is(target error) bool {
+	return target == err.sentinel
+}
+
+// Unwrap returns the actual cause
+func (err *sentinelError) Unwrap() error {
+	return err.cause
+}
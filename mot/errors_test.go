@@ -0,0 +1,26 @@
+package mot
+
+import (
+	"errors"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	cause := errors.New("matrix is singular")
+	err := pkgerrors.Wrap(withSentinel(ErrKalmanUpdate, cause), "Can't update object tracker")
+	if !errors.Is(err, ErrKalmanUpdate) {
+		t.Errorf("error should match ErrKalmanUpdate: %v", err)
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("error should match its cause: %v", err)
+	}
+	if errors.Is(err, ErrInvalidBBox) {
+		t.Errorf("error should not match ErrInvalidBBox: %v", err)
+	}
+	correctMessage := "Can't update object tracker: kalman filter update failed: matrix is singular"
+	if err.Error() != correctMessage {
+		t.Errorf("incorrect message: '%s', expected: '%s'", err.Error(), correctMessage)
+	}
+}
@@ -0,0 +1,246 @@
+package mot
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// EvalObject is labeled bounding box of ground truth or tracker's output on some frame
+type EvalObject struct {
+	ID   string
+	BBox Rectangle
+}
+
+// Evaluation is CLEAR MOT and identity metrics of tracker's output against ground truth
+type Evaluation struct {
+	// Multiple object tracking accuracy: 1 - (FN + FP + IDSW) / GT. It could be negative
+	MOTA float64
+	// Identity F1 score: 2 * IDTP / (GT + hypotheses)
+	IDF1 float64
+	// Total number of ground truth boxes
+	GroundTruth int
+	// Total number of tracker's boxes
+	Hypotheses     int
+	FalsePositives int
+	FalseNegatives int
+	IDSwitches     int
+	// Number of boxes which are matched with ground truth under the best global assignment of identities
+	IDTruePositives int
+}
+
+// Evaluate compares tracker's output with ground truth frame by frame. Boxes are matched when their IoU is not less than iouThreshold
+// (0.5 is common choice): correspondences of the previous frame are kept while they are valid, the rest are matched via Hungarian algorithm.
+// Returns ErrLengthMismatch if number of frames differs
+func Evaluate(groundTruth, hypotheses [][]EvalObject, iouThreshold float64) (Evaluation, error) {
+	if len(groundTruth) != len(hypotheses) {
+		return Evaluation{}, errors.Wrapf(ErrLengthMismatch, "Got %d ground truth frames and %d hypotheses frames", len(groundTruth), len(hypotheses))
+	}
+	evaluation := Evaluation{}
+	// Ground truth identifier -> hypothesis identifier of the latest match
+	lastMatch := make(map[string]string)
+	// Number of frames on which pair of identifiers is matched
+	pairs := make(map[[2]string]int)
+	gtTotals := make(map[string]int)
+	hypTotals := make(map[string]int)
+	for frame := range groundTruth {
+		gt, hyp := groundTruth[frame], hypotheses[frame]
+		evaluation.GroundTruth += len(gt)
+		evaluation.Hypotheses += len(hyp)
+		for _, object := range gt {
+			gtTotals[object.ID]++
+		}
+		for _, object := range hyp {
+			hypTotals[object.ID]++
+		}
+		matches := matchFrame(gt, hyp, lastMatch, iouThreshold)
+		for gtIdx, hypIdx := range matches {
+			if hypIdx < 0 {
+				evaluation.FalseNegatives++
+				continue
+			}
+			gtID, hypID := gt[gtIdx].ID, hyp[hypIdx].ID
+			if previous, ok := lastMatch[gtID]; ok && previous != hypID {
+				evaluation.IDSwitches++
+			}
+			lastMatch[gtID] = hypID
+			pairs[[2]string{gtID, hypID}]++
+		}
+		evaluation.FalsePositives += len(hyp) - (len(gt) - countUnmatched(matches))
+	}
+	if evaluation.GroundTruth > 0 {
+		evaluation.MOTA = 1 - float64(evaluation.FalseNegatives+evaluation.FalsePositives+evaluation.IDSwitches)/float64(evaluation.GroundTruth)
+	}
+	evaluation.IDTruePositives = identityTruePositives(pairs, gtTotals, hypTotals)
+	if total := evaluation.GroundTruth + evaluation.Hypotheses; total > 0 {
+		evaluation.IDF1 = 2 * float64(evaluation.IDTruePositives) / float64(total)
+	}
+	return evaluation, nil
+}
+
+// matchFrame returns index of matched hypothesis for each ground truth box (-1 if there is no match)
+func matchFrame(gt, hyp []EvalObject, lastMatch map[string]string, iouThreshold float64) []int {
+	matches := make([]int, len(gt))
+	usedHyp := make([]bool, len(hyp))
+	for i := range matches {
+		matches[i] = -1
+		previous, ok := lastMatch[gt[i].ID]
+		if !ok {
+			continue
+		}
+		for j := range hyp {
+			if !usedHyp[j] && hyp[j].ID == previous && boxesIoU(gt[i].BBox, hyp[j].BBox) >= iouThreshold {
+				matches[i] = j
+				usedHyp[j] = true
+				break
+			}
+		}
+	}
+	freeGT := make([]int, 0, len(gt))
+	for i := range gt {
+		if matches[i] < 0 {
+			freeGT = append(freeGT, i)
+		}
+	}
+	freeHyp := make([]int, 0, len(hyp))
+	for j := range hyp {
+		if !usedHyp[j] {
+			freeHyp = append(freeHyp, j)
+		}
+	}
+	if len(freeGT) == 0 || len(freeHyp) == 0 {
+		return matches
+	}
+	n := len(freeGT)
+	if len(freeHyp) > n {
+		n = len(freeHyp)
+	}
+	cost := make([][]float64, n)
+	for i := range cost {
+		cost[i] = make([]float64, n)
+		for j := range cost[i] {
+			// Padding and pairs below threshold cost more than any valid pair
+			cost[i][j] = 2
+			if i < len(freeGT) && j < len(freeHyp) {
+				if iou := boxesIoU(gt[freeGT[i]].BBox, hyp[freeHyp[j]].BBox); iou >= iouThreshold {
+					cost[i][j] = 1 - iou
+				}
+			}
+		}
+	}
+	for i, j := range hungarian(cost) {
+		if i < len(freeGT) && j < len(freeHyp) && cost[i][j] <= 1 {
+			matches[freeGT[i]] = freeHyp[j]
+		}
+	}
+	return matches
+}
+
+// countUnmatched returns number of ground truth boxes without match
+func countUnmatched(matches []int) int {
+	unmatched := 0
+	for _, hypIdx := range matches {
+		if hypIdx < 0 {
+			unmatched++
+		}
+	}
+	return unmatched
+}
+
+// identityTruePositives finds one-to-one assignment of ground truth identifiers to hypotheses identifiers
+// which maximizes number of matched boxes
+func identityTruePositives(pairs map[[2]string]int, gtTotals, hypTotals map[string]int) int {
+	if len(pairs) == 0 {
+		return 0
+	}
+	gtIndex := make(map[string]int, len(gtTotals))
+	for id := range gtTotals {
+		gtIndex[id] = len(gtIndex)
+	}
+	hypIndex := make(map[string]int, len(hypTotals))
+	for id := range hypTotals {
+		hypIndex[id] = len(hypIndex)
+	}
+	n := len(gtIndex)
+	if len(hypIndex) > n {
+		n = len(hypIndex)
+	}
+	cost := make([][]float64, n)
+	for i := range cost {
+		cost[i] = make([]float64, n)
+	}
+	for pair, count := range pairs {
+		cost[gtIndex[pair[0]]][hypIndex[pair[1]]] = -float64(count)
+	}
+	total := 0.0
+	for i, j := range hungarian(cost) {
+		total -= cost[i][j]
+	}
+	return int(total)
+}
+
+// boxesIoU returns intersection over union of two rectangles
+func boxesIoU(a, b Rectangle) float64 {
+	return IoUXYWH(a.X, a.Y, a.Width, a.Height, b.X, b.Y, b.Width, b.Height)
+}
+
+// hungarian solves assignment problem for square cost matrix and returns column assigned to each row (minimizing total cost)
+func hungarian(cost [][]float64) []int {
+	n := len(cost)
+	// Potentials and matching are 1-indexed, zero column is fictive
+	u := make([]float64, n+1)
+	v := make([]float64, n+1)
+	p := make([]int, n+1)
+	way := make([]int, n+1)
+	minv := make([]float64, n+1)
+	used := make([]bool, n+1)
+	for i := 1; i <= n; i++ {
+		p[0] = i
+		j0 := 0
+		for j := range minv {
+			minv[j] = math.Inf(1)
+			used[j] = false
+		}
+		for p[j0] != 0 {
+			used[j0] = true
+			i0 := p[j0]
+			delta := math.Inf(1)
+			j1 := 0
+			for j := 1; j <= n; j++ {
+				if used[j] {
+					continue
+				}
+				current := cost[i0-1][j-1] - u[i0] - v[j]
+				if current < minv[j] {
+					minv[j] = current
+					way[j] = j0
+				}
+				if minv[j] < delta {
+					delta = minv[j]
+					j1 = j
+				}
+			}
+			for j := 0; j <= n; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+		}
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+	assignment := make([]int, n)
+	for j := 1; j <= n; j++ {
+		if p[j] > 0 {
+			assignment[p[j]-1] = j - 1
+		}
+	}
+	return assignment
+}
@@ -0,0 +1,60 @@
+package mot
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestEvaluate(t *testing.T) {
+	a := Rectangle{X: 0.0, Y: 0.0, Width: 10.0, Height: 10.0}
+	b := Rectangle{X: 100.0, Y: 0.0, Width: 10.0, Height: 10.0}
+	groundTruth := [][]EvalObject{
+		{{ID: "a", BBox: a}, {ID: "b", BBox: b}},
+		{{ID: "a", BBox: a}, {ID: "b", BBox: b}},
+		{{ID: "a", BBox: a}, {ID: "b", BBox: b}},
+		{{ID: "a", BBox: a}, {ID: "b", BBox: b}},
+	}
+	hypotheses := [][]EvalObject{
+		{{ID: "1", BBox: a}, {ID: "2", BBox: b}},
+		{{ID: "1", BBox: a}, {ID: "2", BBox: b}},
+		// Identity switch and false positive
+		{{ID: "3", BBox: a}, {ID: "2", BBox: b}, {ID: "9", BBox: Rectangle{X: 500.0, Y: 500.0, Width: 10.0, Height: 10.0}}},
+		// False negative
+		{{ID: "3", BBox: a}},
+	}
+	evaluation, err := Evaluate(groundTruth, hypotheses, 0.5)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if evaluation.FalseNegatives != 1 || evaluation.FalsePositives != 1 || evaluation.IDSwitches != 1 {
+		t.Errorf("incorrect errors: FN %v, FP %v, IDSW %v, expected: 1, 1, 1", evaluation.FalseNegatives, evaluation.FalsePositives, evaluation.IDSwitches)
+	}
+	if math.Abs(evaluation.MOTA-0.625) > eps {
+		t.Errorf("incorrect MOTA: %v, expected: %v", evaluation.MOTA, 0.625)
+	}
+	if evaluation.IDTruePositives != 5 || math.Abs(evaluation.IDF1-0.625) > eps {
+		t.Errorf("incorrect IDF1: %v (IDTP: %v), expected: %v (IDTP: %v)", evaluation.IDF1, evaluation.IDTruePositives, 0.625, 5)
+	}
+	_, err = Evaluate(groundTruth, hypotheses[:2], 0.5)
+	if !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("incorrect error: %v, expected: %v", err, ErrLengthMismatch)
+	}
+}
+
+func TestHungarian(t *testing.T) {
+	cost := [][]float64{
+		{4, 1, 3},
+		{2, 0, 5},
+		{3, 2, 2},
+	}
+	assignment := hungarian(cost)
+	total := 0.0
+	for i, j := range assignment {
+		total += cost[i][j]
+	}
+	if total != 5 {
+		t.Errorf("incorrect total cost: %v (assignment %v), expected: %v", total, assignment, 5.0)
+	}
+}
@@ -0,0 +1,121 @@
+package mot
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// BackpressurePolicy defines what EventStream does when its buffer is full
+type BackpressurePolicy uint16
+
+const (
+	// BackpressureBlock blocks MatchObjects until consumer reads events
+	BackpressureBlock = BackpressurePolicy(iota)
+	// BackpressureDropNewest drops the event which could not be buffered
+	BackpressureDropNewest
+	// BackpressureDropOldest drops the oldest buffered event to make room for the new one
+	BackpressureDropOldest
+)
+
+// EventStream delivers track lifecycle events through buffered channel
+type EventStream struct {
+	events  chan Event
+	policy  BackpressurePolicy
+	dropped uint64
+	mu      sync.RWMutex
+	closed  bool
+	// Closed by Close to unblock producers which wait for consumer
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewEventStream creates event stream with given buffer size and backpressure policy.
+// Pass its Handle method to OnEvent or use EventStream method of tracker
+func NewEventStream(bufferSize int, policy BackpressurePolicy) *EventStream {
+	if bufferSize < 0 {
+		bufferSize = 0
+	}
+	return &EventStream{
+		events: make(chan Event, bufferSize),
+		policy: policy,
+		done:   make(chan struct{}),
+	}
+}
+
+// EventStream creates event stream and subscribes it to tracker's events
+func (tracker *SimpleTracker) EventStream(bufferSize int, policy BackpressurePolicy) *EventStream {
+	stream := NewEventStream(bufferSize, policy)
+	tracker.OnEvent(stream.Handle)
+	return stream
+}
+
+// EventStream creates event stream and subscribes it to events of all tiles
+func (tracker *TiledTracker) EventStream(bufferSize int, policy BackpressurePolicy) *EventStream {
+	stream := NewEventStream(bufferSize, policy)
+	tracker.OnEvent(stream.Handle)
+	return stream
+}
+
+// Events returns channel with events. It is closed by Close
+func (stream *EventStream) Events() <-chan Event {
+	return stream.events
+}
+
+// Dropped returns number of events which have been dropped due backpressure policy or because stream has been closed
+func (stream *EventStream) Dropped() uint64 {
+	return atomic.LoadUint64(&stream.dropped)
+}
+
+// Handle puts event into the stream according to backpressure policy. It is safe for concurrent use
+func (stream *EventStream) Handle(event Event) {
+	stream.mu.RLock()
+	defer stream.mu.RUnlock()
+	if stream.closed {
+		atomic.AddUint64(&stream.dropped, 1)
+		return
+	}
+	switch stream.policy {
+	case BackpressureDropNewest:
+		select {
+		case stream.events <- event:
+		default:
+			atomic.AddUint64(&stream.dropped, 1)
+		}
+	case BackpressureDropOldest:
+		for {
+			select {
+			case stream.events <- event:
+				return
+			default:
+			}
+			select {
+			case <-stream.events:
+				atomic.AddUint64(&stream.dropped, 1)
+			default:
+				// Unbuffered stream without waiting consumer: nothing to drop except the event itself
+				if cap(stream.events) == 0 {
+					atomic.AddUint64(&stream.dropped, 1)
+					return
+				}
+			}
+		}
+	default:
+		select {
+		case stream.events <- event:
+		case <-stream.done:
+			atomic.AddUint64(&stream.dropped, 1)
+		}
+	}
+}
+
+// Close closes events channel. Events which are emitted after that are dropped.
+// With BackpressureBlock policy Handle calls which are blocked by stopped consumer are released and their events are dropped as well
+func (stream *EventStream) Close() {
+	stream.closeOnce.Do(func() {
+		close(stream.done)
+		stream.mu.Lock()
+		defer stream.mu.Unlock()
+		stream.closed = true
+		close(stream.events)
+	})
+}
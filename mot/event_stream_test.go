@@ -0,0 +1,77 @@
+package mot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventStreamPolicies(t *testing.T) {
+	events := []Event{{Frame: 0}, {Frame: 1}, {Frame: 2}}
+	expectedFrames := map[BackpressurePolicy][]int{
+		BackpressureDropNewest: {0, 1},
+		BackpressureDropOldest: {1, 2},
+	}
+	for policy, expected := range expectedFrames {
+		stream := NewEventStream(2, policy)
+		for _, event := range events {
+			stream.Handle(event)
+		}
+		stream.Close()
+		frames := make([]int, 0)
+		for event := range stream.Events() {
+			frames = append(frames, event.Frame)
+		}
+		if len(frames) != len(expected) || frames[0] != expected[0] || frames[1] != expected[1] {
+			t.Errorf("incorrect delivered events (policy=%d): %v, expected: %v", policy, frames, expected)
+		}
+		if stream.Dropped() != 1 {
+			t.Errorf("incorrect number of dropped events (policy=%d): %d, expected: %d", policy, stream.Dropped(), 1)
+		}
+	}
+}
+
+func TestTrackerEventStream(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10))
+	stream := tracker.EventStream(0, BackpressureBlock)
+	done := make(chan []Event)
+	go func() {
+		received := make([]Event, 0)
+		for event := range stream.Events() {
+			received = append(received, event)
+		}
+		done <- received
+	}()
+	err := tracker.MatchObjects([]*SimpleBlob{NewSimpleBlob(Rectangle{X: 10.0, Y: 10.0, Width: 20.0, Height: 20.0})})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	stream.Close()
+	received := <-done
+	if len(received) != 1 || received[0].Type != EventTrackCreated {
+		t.Errorf("incorrect received events: %v, expected single %s event", received, EventTrackCreated)
+	}
+}
+
+func TestEventStreamCloseUnblocks(t *testing.T) {
+	stream := NewEventStream(1, BackpressureBlock)
+	stream.Handle(Event{Frame: 0})
+	handled := make(chan struct{})
+	go func() {
+		// Buffer is full and nobody reads it, so this call blocks
+		stream.Handle(Event{Frame: 1})
+		close(handled)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	stream.Close()
+	select {
+	case <-handled:
+	case <-time.After(time.Second):
+		t.Errorf("Close should release blocked Handle call")
+		return
+	}
+	if stream.Dropped() != 1 {
+		t.Errorf("incorrect number of dropped events: %d, expected: %d", stream.Dropped(), 1)
+	}
+	stream.Close()
+}
@@ -0,0 +1,110 @@
+package mot
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType is the kind of track lifecycle event
+type EventType uint16
+
+const (
+	// EventTrackCreated is emitted when new track becomes visible (see SimpleTracker.SetMinConsecutiveMatches)
+	EventTrackCreated = EventType(iota)
+	// EventTrackUpdated is emitted when visible track is matched with new detection
+	EventTrackUpdated
+	// EventTrackLost is emitted when visible track has not been matched after being matched on the previous frame
+	EventTrackLost
+	// EventTrackRemoved is emitted when visible track is removed from tracker
+	EventTrackRemoved
+	// EventSuspectedTeleport is emitted when visible track has been matched with detection which is implausibly far from it
+	// (see SimpleTracker.SetIDSwitchMonitor)
+	EventSuspectedTeleport
+	// EventSuspectedSwap is emitted for each of two visible tracks which seem to have exchanged their positions (see SimpleTracker.SetIDSwitchMonitor)
+	EventSuspectedSwap
+)
+
+// String returns name of event type
+func (eventType EventType) String() string {
+	switch eventType {
+	case EventTrackCreated:
+		return "created"
+	case EventTrackUpdated:
+		return "updated"
+	case EventTrackLost:
+		return "lost"
+	case EventTrackRemoved:
+		return "removed"
+	case EventSuspectedTeleport:
+		return "suspected_teleport"
+	case EventSuspectedSwap:
+		return "suspected_swap"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is track lifecycle event
+type Event struct {
+	Type EventType
+	// Identifier of track
+	TrackID uuid.UUID
+	// Compact display identifier of track (zero if display identifiers are disabled)
+	DisplayID int
+	// Object class of track (empty if it is unknown)
+	Class string
+	// Track's bounding box at the moment of event
+	BBox Rectangle
+	// Track's confidence at the moment of event. It decays while track is coasting (see SimpleTracker.SetConfidenceDecay)
+	Confidence float64
+	// The other track which event relates to (e.g. swap partner). Zero for most of events
+	RelatedTrackID uuid.UUID
+	// Index of frame (starting from zero) during which event has happened
+	Frame int
+	// Time of the frame (time of processing unless it has been provided via MatchObjectsAt)
+	Timestamp time.Time
+}
+
+// OnEvent subscribes given function to track lifecycle events. Functions are called synchronously during MatchObjects
+// in order of subscription, so they should not block
+func (tracker *SimpleTracker) OnEvent(fn func(event Event)) {
+	tracker.eventHandlers = append(tracker.eventHandlers, fn)
+}
+
+// OnEvent subscribes given function to track lifecycle events of all tiles.
+// Tiles are processed in parallel, so the function must be safe for concurrent use
+func (tracker *TiledTracker) OnEvent(fn func(event Event)) {
+	for _, tile := range tracker.tiles {
+		tile.OnEvent(fn)
+	}
+}
+
+// emit sends event about given track to subscribers
+func (tracker *SimpleTracker) emit(eventType EventType, track *SimpleBlob) {
+	if len(tracker.eventHandlers) == 0 {
+		return
+	}
+	tracker.dispatch(tracker.newEvent(eventType, track))
+}
+
+// newEvent creates event about given track
+func (tracker *SimpleTracker) newEvent(eventType EventType, track *SimpleBlob) Event {
+	return Event{
+		Type:       eventType,
+		TrackID:    track.id,
+		DisplayID:  track.displayID,
+		Class:      track.class,
+		BBox:       track.currentBBox,
+		Confidence: track.confidence,
+		Frame:      tracker.counters.framesProcessed,
+		Timestamp:  tracker.frameTime,
+	}
+}
+
+// dispatch sends event to subscribers
+func (tracker *SimpleTracker) dispatch(event Event) {
+	for _, fn := range tracker.eventHandlers {
+		fn(event)
+	}
+}
@@ -0,0 +1,37 @@
+package mot
+
+import (
+	"testing"
+)
+
+func TestOnEvent(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(2))
+	events := make([]Event, 0)
+	tracker.OnEvent(func(event Event) {
+		events = append(events, event)
+	})
+	blob := NewSimpleBlob(Rectangle{X: 10.0, Y: 10.0, Width: 20.0, Height: 20.0})
+	frames := [][]*SimpleBlob{
+		{blob},
+		{NewSimpleBlob(Rectangle{X: 11.0, Y: 10.0, Width: 20.0, Height: 20.0})},
+		{},
+		{},
+	}
+	for _, frame := range frames {
+		err := tracker.MatchObjects(frame)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	expected := []EventType{EventTrackCreated, EventTrackUpdated, EventTrackLost, EventTrackRemoved}
+	if len(events) != len(expected) {
+		t.Errorf("incorrect number of events: %d, expected: %d", len(events), len(expected))
+		return
+	}
+	for i, event := range events {
+		if event.Type != expected[i] || event.Frame != i || event.TrackID != blob.GetID() || event.Timestamp.IsZero() {
+			t.Errorf("incorrect event %d: %+v, expected %s event for track %s on frame %d", i, event, expected[i], blob.GetID(), i)
+		}
+	}
+}
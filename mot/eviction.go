@@ -0,0 +1,73 @@
+package mot
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// EvictionPolicy defines which tracks are removed when tracker exceeds its max number of objects
+type EvictionPolicy uint16
+
+const (
+	// EvictOldest removes tracks which have been registered earlier than others
+	EvictOldest = EvictionPolicy(iota)
+	// EvictLongestUnmatched removes tracks which have not been matched for the longest time
+	EvictLongestUnmatched
+	// EvictLowestConfidence removes tracks with the lowest confidence
+	EvictLowestConfidence
+)
+
+// SetMaxObjects bounds number of tracks kept by tracker. Zero means no limit.
+// Excess tracks are removed at the end of each MatchObjects call according to the policy.
+// Pinned tracks (see PinTrack) are never evicted, so tracker could keep more tracks if too many of them are pinned
+func (tracker *SimpleTracker) SetMaxObjects(maxObjects int, policy EvictionPolicy) {
+	if maxObjects < 0 {
+		maxObjects = 0
+	}
+	tracker.maxObjects = maxObjects
+	tracker.evictionPolicy = policy
+}
+
+// GetMaxObjects returns max number of tracks and eviction policy
+func (tracker *SimpleTracker) GetMaxObjects() (int, EvictionPolicy) {
+	return tracker.maxObjects, tracker.evictionPolicy
+}
+
+// evictExcessTracks removes tracks exceeding maxObjects limit
+func (tracker *SimpleTracker) evictExcessTracks() {
+	excess := tracker.storage.len() - tracker.maxObjects
+	if tracker.maxObjects == 0 || excess <= 0 {
+		return
+	}
+	// Storage is ordered by registration time, so the oldest tracks go first
+	candidates := make([]*SimpleBlob, 0, tracker.storage.len())
+	for _, object := range tracker.storage.tracks {
+		if !object.pinned {
+			candidates = append(candidates, object)
+		}
+	}
+	if excess > len(candidates) {
+		excess = len(candidates)
+	}
+	switch tracker.evictionPolicy {
+	case EvictLongestUnmatched:
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].noMatchTimes > candidates[j].noMatchTimes
+		})
+	case EvictLowestConfidence:
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].confidence < candidates[j].confidence
+		})
+	default:
+		// EvictOldest: nothing to do
+	}
+	victims := make(map[uuid.UUID]struct{}, excess)
+	for _, candidate := range candidates[:excess] {
+		victims[candidate.id] = struct{}{}
+	}
+	tracker.storage.retain(func(object *SimpleBlob) bool {
+		_, evict := victims[object.id]
+		return !evict
+	}, tracker.onTrackRemoved)
+}
@@ -0,0 +1,74 @@
+package mot
+
+import (
+	"testing"
+)
+
+func TestEvictionPolicies(t *testing.T) {
+	dt := 1.0 / 25.0
+	policies := []EvictionPolicy{EvictOldest, EvictLongestUnmatched, EvictLowestConfidence}
+	for _, policy := range policies {
+		tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10), WithConfidenceDecay(0.5))
+		tracker.SetMaxObjects(2, policy)
+		first := NewSimpleBlobWithTime(NewRect(10.0, 10.0, 20.0, 20.0), dt)
+		second := NewSimpleBlobWithTime(NewRect(200.0, 10.0, 20.0, 20.0), dt)
+		err := tracker.MatchObjects([]*SimpleBlob{first, second})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		// The first object keeps being matched while the second one disappears and the third one appears
+		third := NewSimpleBlobWithTime(NewRect(400.0, 10.0, 20.0, 20.0), dt)
+		err = tracker.MatchObjects([]*SimpleBlob{NewSimpleBlobWithTime(NewRect(11.0, 10.0, 20.0, 20.0), dt), third})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if tracker.storage.len() != 2 {
+			t.Errorf("incorrect number of tracks for policy %d: %d, expected: %d", policy, tracker.storage.len(), 2)
+			continue
+		}
+		expectedEvicted := first.GetID()
+		if policy == EvictLongestUnmatched || policy == EvictLowestConfidence {
+			expectedEvicted = second.GetID()
+		}
+		if _, ok := tracker.Objects[expectedEvicted]; ok {
+			t.Errorf("track %s should be evicted for policy %d", expectedEvicted, policy)
+		}
+		if tracker.Stats().TracksRemoved != 1 {
+			t.Errorf("incorrect number of removed tracks for policy %d: %d, expected: %d", policy, tracker.Stats().TracksRemoved, 1)
+		}
+	}
+}
+
+func TestEvictionKeepsPinnedTracks(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10))
+	tracker.SetMaxObjects(2, EvictOldest)
+	pinned := NewSimpleBlob(NewRect(10.0, 10.0, 20.0, 20.0))
+	err := tracker.MatchObjects([]*SimpleBlob{pinned})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err = tracker.PinTrack(pinned.GetID()); err != nil {
+		t.Error(err)
+		return
+	}
+	second := NewSimpleBlob(NewRect(200.0, 10.0, 20.0, 20.0))
+	third := NewSimpleBlob(NewRect(400.0, 10.0, 20.0, 20.0))
+	err = tracker.MatchObjects([]*SimpleBlob{second, third})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	// Pinned track is the oldest one, but only unpinned tracks could be evicted
+	if _, ok := tracker.Objects[pinned.GetID()]; !ok {
+		t.Errorf("pinned track %s should not be evicted", pinned.GetID())
+	}
+	if _, ok := tracker.Objects[second.GetID()]; ok {
+		t.Errorf("track %s should be evicted", second.GetID())
+	}
+	if tracker.storage.len() != 2 {
+		t.Errorf("incorrect number of tracks: %d, expected: %d", tracker.storage.len(), 2)
+	}
+}
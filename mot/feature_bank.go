@@ -0,0 +1,131 @@
+package mot
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FeatureBankEntry is appearance of track which has been removed from tracker
+type FeatureBankEntry struct {
+	// Identifier of removed track
+	TrackID uuid.UUID
+	// Recent appearance features of the track
+	Features [][]float32
+	// Time of removal
+	RemovedAt time.Time
+}
+
+// FeatureBank keeps appearance features of recently removed tracks, so their identifiers could be restored
+// when the same objects appear again. It is safe for concurrent use and could be shared between trackers
+type FeatureBank struct {
+	mu       sync.Mutex
+	capacity int
+	entries  []FeatureBankEntry
+}
+
+// NewFeatureBank creates bank which keeps up to capacity entries (the oldest ones are dropped first). Zero means no limit
+func NewFeatureBank(capacity int) *FeatureBank {
+	if capacity < 0 {
+		capacity = 0
+	}
+	return &FeatureBank{
+		capacity: capacity,
+		entries:  make([]FeatureBankEntry, 0),
+	}
+}
+
+// Add puts entry into the bank replacing existing entry of the same track
+func (bank *FeatureBank) Add(entry FeatureBankEntry) {
+	if len(entry.Features) == 0 {
+		return
+	}
+	bank.mu.Lock()
+	defer bank.mu.Unlock()
+	bank.remove(entry.TrackID)
+	if bank.capacity > 0 && len(bank.entries) >= bank.capacity {
+		n := copy(bank.entries, bank.entries[len(bank.entries)-bank.capacity+1:])
+		for i := n; i < len(bank.entries); i++ {
+			bank.entries[i] = FeatureBankEntry{}
+		}
+		bank.entries = bank.entries[:n]
+	}
+	bank.entries = append(bank.entries, entry)
+}
+
+// Remove deletes entry of the given track. Returns false if there is no such entry
+func (bank *FeatureBank) Remove(id uuid.UUID) bool {
+	bank.mu.Lock()
+	defer bank.mu.Unlock()
+	return bank.remove(id)
+}
+
+func (bank *FeatureBank) remove(id uuid.UUID) bool {
+	for i := range bank.entries {
+		if bank.entries[i].TrackID != id {
+			continue
+		}
+		copy(bank.entries[i:], bank.entries[i+1:])
+		bank.entries[len(bank.entries)-1] = FeatureBankEntry{}
+		bank.entries = bank.entries[:len(bank.entries)-1]
+		return true
+	}
+	return false
+}
+
+// Expire drops entries which have been removed before the given time
+func (bank *FeatureBank) Expire(before time.Time) {
+	bank.mu.Lock()
+	defer bank.mu.Unlock()
+	n := 0
+	for _, entry := range bank.entries {
+		if entry.RemovedAt.Before(before) {
+			continue
+		}
+		bank.entries[n] = entry
+		n++
+	}
+	for i := n; i < len(bank.entries); i++ {
+		bank.entries[i] = FeatureBankEntry{}
+	}
+	bank.entries = bank.entries[:n]
+}
+
+// Match searches for the entry with the closest appearance (minimum cosine distance over entry's features).
+// Only entries within maxCosineDistance are considered
+func (bank *FeatureBank) Match(feature []float32, maxCosineDistance float64) (FeatureBankEntry, float64, bool) {
+	bank.mu.Lock()
+	defer bank.mu.Unlock()
+	bestIdx := -1
+	bestDistance := 0.0
+	for i := range bank.entries {
+		for _, bankFeature := range bank.entries[i].Features {
+			distance, ok := cosineDistance32(bankFeature, feature)
+			if !ok || distance > maxCosineDistance {
+				continue
+			}
+			if bestIdx < 0 || distance < bestDistance {
+				bestIdx, bestDistance = i, distance
+			}
+		}
+	}
+	if bestIdx < 0 {
+		return FeatureBankEntry{}, 0, false
+	}
+	return bank.entries[bestIdx], bestDistance, true
+}
+
+// Entries returns copy of bank's entries (the oldest first)
+func (bank *FeatureBank) Entries() []FeatureBankEntry {
+	bank.mu.Lock()
+	defer bank.mu.Unlock()
+	return append([]FeatureBankEntry{}, bank.entries...)
+}
+
+// Len returns number of entries in the bank
+func (bank *FeatureBank) Len() int {
+	bank.mu.Lock()
+	defer bank.mu.Unlock()
+	return len(bank.entries)
+}
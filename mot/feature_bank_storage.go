@@ -0,0 +1,123 @@
+package mot
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// featureBankVersion is version of feature bank format. It is increased on incompatible changes
+const featureBankVersion = 1
+
+// KVStore is minimal key-value storage which feature bank could be persisted to (e.g. adapter for Redis or BoltDB)
+type KVStore interface {
+	Put(key string, value []byte) error
+	// Get returns nil value without error if there is no such key
+	Get(key string) ([]byte, error)
+}
+
+// featureBankData is serialized feature bank
+type featureBankData struct {
+	Version int                 `json:"version"`
+	Entries []featureBankRecord `json:"entries"`
+}
+
+// featureBankRecord is serialized FeatureBankEntry
+type featureBankRecord struct {
+	TrackID   uuid.UUID   `json:"track_id"`
+	Features  [][]float32 `json:"features"`
+	RemovedAt time.Time   `json:"removed_at"`
+}
+
+// Save writes bank's entries to w
+func (bank *FeatureBank) Save(w io.Writer) error {
+	entries := bank.Entries()
+	data := featureBankData{
+		Version: featureBankVersion,
+		Entries: make([]featureBankRecord, len(entries)),
+	}
+	for i, entry := range entries {
+		data.Entries[i] = featureBankRecord{TrackID: entry.TrackID, Features: entry.Features, RemovedAt: entry.RemovedAt}
+	}
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		return errors.Wrap(err, "Can't encode feature bank")
+	}
+	return nil
+}
+
+// Load replaces bank's entries with the ones read from r. If there are more entries than bank's capacity, the newest ones are kept
+func (bank *FeatureBank) Load(r io.Reader) error {
+	data := featureBankData{}
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return errors.Wrapf(withSentinel(ErrInvalidSnapshot, err), "Can't decode feature bank")
+	}
+	if data.Version != featureBankVersion {
+		return errors.Wrapf(ErrInvalidSnapshot, "Unsupported feature bank version %d (expected %d)", data.Version, featureBankVersion)
+	}
+	entries := make([]FeatureBankEntry, 0, len(data.Entries))
+	for _, record := range data.Entries {
+		entries = append(entries, FeatureBankEntry{TrackID: record.TrackID, Features: record.Features, RemovedAt: record.RemovedAt})
+	}
+	bank.mu.Lock()
+	defer bank.mu.Unlock()
+	if bank.capacity > 0 && len(entries) > bank.capacity {
+		entries = entries[len(entries)-bank.capacity:]
+	}
+	bank.entries = entries
+	return nil
+}
+
+// SaveFile writes bank's entries to the file (it is created or truncated)
+func (bank *FeatureBank) SaveFile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "Can't create file %s", path)
+	}
+	if err = bank.Save(file); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return errors.Wrapf(err, "Can't close file %s", path)
+	}
+	return nil
+}
+
+// LoadFile replaces bank's entries with the ones read from the file
+func (bank *FeatureBank) LoadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "Can't open file %s", path)
+	}
+	defer file.Close()
+	return bank.Load(file)
+}
+
+// SaveTo puts bank's entries into key-value store under the given key
+func (bank *FeatureBank) SaveTo(store KVStore, key string) error {
+	buf := bytes.Buffer{}
+	if err := bank.Save(&buf); err != nil {
+		return err
+	}
+	if err := store.Put(key, buf.Bytes()); err != nil {
+		return errors.Wrapf(err, "Can't put feature bank to key %s", key)
+	}
+	return nil
+}
+
+// LoadFrom replaces bank's entries with the ones stored under the given key. Missing key leaves bank untouched
+func (bank *FeatureBank) LoadFrom(store KVStore, key string) error {
+	value, err := store.Get(key)
+	if err != nil {
+		return errors.Wrapf(err, "Can't get feature bank from key %s", key)
+	}
+	if value == nil {
+		return nil
+	}
+	return bank.Load(bytes.NewReader(value))
+}
@@ -0,0 +1,67 @@
+package mot
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// memoryStore is in-memory KVStore for tests
+type memoryStore map[string][]byte
+
+func (store memoryStore) Put(key string, value []byte) error {
+	store[key] = append([]byte{}, value...)
+	return nil
+}
+
+func (store memoryStore) Get(key string) ([]byte, error) {
+	return store[key], nil
+}
+
+func TestFeatureBankStorage(t *testing.T) {
+	bank := NewFeatureBank(0)
+	removedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+	bank.Add(FeatureBankEntry{TrackID: ids[0], Features: [][]float32{{1, 0}}, RemovedAt: removedAt})
+	bank.Add(FeatureBankEntry{TrackID: ids[1], Features: [][]float32{{0, 1}, {0.1, 1}}, RemovedAt: removedAt.Add(time.Second)})
+
+	path := filepath.Join(t.TempDir(), "bank.json")
+	if err := bank.SaveFile(path); err != nil {
+		t.Error(err)
+		return
+	}
+	fromFile := NewFeatureBank(0)
+	if err := fromFile.LoadFile(path); err != nil {
+		t.Error(err)
+		return
+	}
+	store := memoryStore{}
+	if err := bank.SaveTo(store, "camera-1"); err != nil {
+		t.Error(err)
+		return
+	}
+	// Capacity of the bank is smaller than number of stored entries, so only the newest one should be loaded
+	fromStore := NewFeatureBank(1)
+	if err := fromStore.LoadFrom(store, "camera-1"); err != nil {
+		t.Error(err)
+		return
+	}
+	entries := fromFile.Entries()
+	if len(entries) != 2 || entries[1].TrackID != ids[1] || len(entries[1].Features) != 2 || !entries[1].RemovedAt.Equal(removedAt.Add(time.Second)) {
+		t.Errorf("incorrect entries loaded from file: %v", entries)
+	}
+	entries = fromStore.Entries()
+	if len(entries) != 1 || entries[0].TrackID != ids[1] {
+		t.Errorf("incorrect entries loaded from store: %v", entries)
+	}
+	if err := fromStore.LoadFrom(store, "missing"); err != nil || fromStore.Len() != 1 {
+		t.Errorf("missing key should leave bank untouched: %v, %d entries", err, fromStore.Len())
+	}
+	if err := fromStore.Load(strings.NewReader(`{"version":100}`)); !errors.Is(err, ErrInvalidSnapshot) {
+		t.Errorf("incorrect error for unsupported version: %v, expected: %v", err, ErrInvalidSnapshot)
+	}
+}
@@ -0,0 +1,84 @@
+package mot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestFeatureBank(t *testing.T) {
+	bank := NewFeatureBank(2)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	features := [][]float32{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	for i, id := range ids {
+		bank.Add(FeatureBankEntry{TrackID: id, Features: [][]float32{features[i]}, RemovedAt: start.Add(time.Duration(i) * time.Second)})
+	}
+	if bank.Len() != 2 {
+		t.Errorf("incorrect number of entries: %d, expected: %d", bank.Len(), 2)
+	}
+	if _, _, ok := bank.Match([]float32{1, 0, 0}, 0.1); ok {
+		t.Errorf("the oldest entry should have been dropped due capacity")
+	}
+	entry, distance, ok := bank.Match([]float32{0, 0.1, 1}, 0.1)
+	if !ok || entry.TrackID != ids[2] || distance > 0.1 {
+		t.Errorf("incorrect match: %v (distance %f), expected: %s", entry.TrackID, distance, ids[2])
+	}
+	bank.Expire(start.Add(2 * time.Second))
+	if bank.Len() != 1 || bank.Entries()[0].TrackID != ids[2] {
+		t.Errorf("incorrect entries after expiration: %v", bank.Entries())
+	}
+	if !bank.Remove(ids[2]) || bank.Remove(ids[2]) {
+		t.Errorf("entry should be removed exactly once")
+	}
+}
+
+func TestReIDRecovery(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(1), WithFeatureBudget(3))
+	bank := NewFeatureBank(0)
+	tracker.SetReIDRecovery(bank, 0.2, 0)
+	person := NewSimpleBlob(Rectangle{X: 10.0, Y: 10.0, Width: 20.0, Height: 40.0})
+	person.SetFeature([]float32{0.2, 0.9, 0.1})
+	result, err := tracker.MatchObjectsWithResult([]*SimpleBlob{person})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	personID := result.Created[0].TrackID
+	// Person is hidden behind the truck for a long time
+	for i := 0; i < 5; i++ {
+		err = tracker.MatchObjects([]*SimpleBlob{})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if len(tracker.Objects) != 0 || bank.Len() != 1 {
+		t.Errorf("removed track should be in the bank: %d tracks, %d bank entries", len(tracker.Objects), bank.Len())
+		return
+	}
+	// Person appears far away from the place where it has been lost
+	reappeared := NewSimpleBlob(Rectangle{X: 300.0, Y: 10.0, Width: 20.0, Height: 40.0})
+	reappeared.SetFeature([]float32{0.25, 0.9, 0.1})
+	stranger := NewSimpleBlob(Rectangle{X: 100.0, Y: 10.0, Width: 20.0, Height: 40.0})
+	stranger.SetFeature([]float32{0.9, 0.1, 0.2})
+	result, err = tracker.MatchObjectsWithResult([]*SimpleBlob{stranger, reappeared})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result.Created) != 2 {
+		t.Errorf("incorrect number of created tracks: %d, expected: %d", len(result.Created), 2)
+		return
+	}
+	for _, created := range result.Created {
+		recovered := created.TrackID == personID
+		if recovered != (created.DetectionIndex == 1) || recovered != created.Resurrected {
+			t.Errorf("incorrect recovery of detection %d: %v, expected only detection 1 to get id %s", created.DetectionIndex, created, personID)
+		}
+	}
+	if bank.Len() != 0 {
+		t.Errorf("recovered entry should be taken out of the bank, got %d entries", bank.Len())
+	}
+}
@@ -0,0 +1,106 @@
+package mot
+
+import (
+	"math"
+)
+
+// SetFeatureBudget sets max number of recent appearance features which are kept in gallery of each track.
+// Distance between detection and track is the minimum over the gallery. Zero disables gallery: only the latest feature is used
+func (tracker *SimpleTracker) SetFeatureBudget(budget int) {
+	if budget < 0 {
+		budget = 0
+	}
+	tracker.featureBudget = budget
+}
+
+// GetFeatureBudget returns max number of appearance features which are kept in gallery of each track
+func (tracker *SimpleTracker) GetFeatureBudget() int {
+	return tracker.featureBudget
+}
+
+// SetAppearanceGate sets max cosine distance between appearance features of detection and track which could be matched.
+// Pairs are checked only if both detection and track have features. Zero disables the gate
+func (tracker *SimpleTracker) SetAppearanceGate(maxCosineDistance float64) {
+	tracker.appearanceGate = maxCosineDistance
+}
+
+// GetAppearanceGate returns max cosine distance between appearance features of detection and track which could be matched
+func (tracker *SimpleTracker) GetAppearanceGate() float64 {
+	return tracker.appearanceGate
+}
+
+// GetGallery returns blob's recent appearance features (the oldest first). Be careful: this is not copy of gallery, but reference to it
+func (blob *SimpleBlob) GetGallery() [][]float32 {
+	return blob.gallery
+}
+
+// ClearGallery drops blob's recent appearance features (e.g. when they have been polluted by occluder)
+func (blob *SimpleBlob) ClearGallery() {
+	for i := range blob.gallery {
+		blob.gallery[i] = nil
+	}
+	blob.gallery = blob.gallery[:0]
+}
+
+// AppearanceDistance returns the minimum cosine distance between given feature and blob's gallery
+// (or blob's latest feature if gallery is empty). Returns false if there is nothing to compare with
+func (blob *SimpleBlob) AppearanceDistance(feature []float32) (float64, bool) {
+	if len(blob.gallery) == 0 {
+		return cosineDistance32(blob.feature, feature)
+	}
+	minDistance := math.MaxFloat64
+	found := false
+	for _, galleryFeature := range blob.gallery {
+		if distance, ok := cosineDistance32(galleryFeature, feature); ok && distance < minDistance {
+			minDistance = distance
+			found = true
+		}
+	}
+	return minDistance, found
+}
+
+// pushFeature adds track's latest feature to its gallery
+func (tracker *SimpleTracker) pushFeature(track *SimpleBlob) {
+	if tracker.featureBudget <= 0 || len(track.feature) == 0 {
+		return
+	}
+	track.gallery = appendBounded(track.gallery, track.feature, tracker.featureBudget)
+}
+
+// mergeGalleries joins galleries of two tracks of the same object (the older one first) keeping at most budget latest features
+func mergeGalleries(older, newer [][]float32, budget int) [][]float32 {
+	merged := make([][]float32, 0, len(older)+len(newer))
+	merged = append(merged, older...)
+	merged = append(merged, newer...)
+	if len(merged) > budget {
+		merged = merged[len(merged)-budget:]
+	}
+	return merged
+}
+
+// appearanceCompatible checks whether detection could be matched with track by appearance
+func (tracker *SimpleTracker) appearanceCompatible(newObject *SimpleBlob, object *SimpleBlob) bool {
+	if tracker.appearanceGate <= 0 || len(newObject.feature) == 0 {
+		return true
+	}
+	distance, ok := object.AppearanceDistance(newObject.feature)
+	return !ok || distance <= tracker.appearanceGate
+}
+
+// cosineDistance32 returns cosine distance (1 - cosine similarity) between two features.
+// Returns false if features have different lengths or one of them has zero norm
+func cosineDistance32(a, b []float32) (float64, bool) {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0, false
+	}
+	dot, normA, normB := 0.0, 0.0, 0.0
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0, false
+	}
+	return 1 - dot/math.Sqrt(normA*normB), true
+}
@@ -0,0 +1,70 @@
+package mot
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCosineDistance32(t *testing.T) {
+	distance, ok := cosineDistance32([]float32{1, 0}, []float32{0, 1})
+	if !ok || math.Abs(distance-1.0) > 1e-9 {
+		t.Errorf("incorrect cosine distance: %f, expected: %f", distance, 1.0)
+	}
+	if _, ok = cosineDistance32([]float32{1, 0}, []float32{1, 0, 0}); ok {
+		t.Errorf("features of different length should not be compared")
+	}
+	if _, ok = cosineDistance32([]float32{0, 0}, []float32{1, 0}); ok {
+		t.Errorf("features with zero norm should not be compared")
+	}
+}
+
+func TestFeatureGallery(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10), WithFeatureBudget(2), WithAppearanceGate(0.3))
+	features := [][]float32{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	for i, feature := range features {
+		detection := NewSimpleBlob(Rectangle{X: 10.0 + float64(i), Y: 10.0, Width: 20.0, Height: 20.0})
+		detection.SetFeature(feature)
+		// Appearance gate is disabled while gallery is filled in, so detections with different features are matched
+		tracker.SetAppearanceGate(0)
+		err := tracker.MatchObjects([]*SimpleBlob{detection})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	tracker.SetAppearanceGate(0.3)
+	track := tracker.GetTracks()[0]
+	gallery := track.GetGallery()
+	if len(gallery) != 2 || gallery[0][1] != 1 || gallery[1][2] != 1 {
+		t.Errorf("incorrect gallery: %v, expected the latest 2 features", gallery)
+	}
+	if distance, ok := track.AppearanceDistance([]float32{0, 1, 0}); !ok || distance > 1e-9 {
+		t.Errorf("incorrect appearance distance to gallery feature: %f, expected: %f", distance, 0.0)
+	}
+	// Detection which looks different should not be matched despite being close
+	stranger := NewSimpleBlob(Rectangle{X: 13.0, Y: 10.0, Width: 20.0, Height: 20.0})
+	stranger.SetFeature([]float32{1, 0, 0})
+	result, err := tracker.MatchObjectsWithResult([]*SimpleBlob{stranger})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result.Matched) != 0 || len(result.Created) != 1 {
+		t.Errorf("incorrect association of detection with different appearance: %v", result)
+	}
+	// Detection which looks like one of gallery features should be matched
+	known := NewSimpleBlob(Rectangle{X: 14.0, Y: 10.0, Width: 20.0, Height: 20.0})
+	known.SetFeature([]float32{0, 0.9, 0.1})
+	result, err = tracker.MatchObjectsWithResult([]*SimpleBlob{known})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result.Matched) != 1 || result.Matched[0].TrackID != track.GetID() {
+		t.Errorf("incorrect association of detection with known appearance: %v", result)
+	}
+	track.ClearGallery()
+	if len(track.GetGallery()) != 0 {
+		t.Errorf("gallery should be empty after clearing")
+	}
+}
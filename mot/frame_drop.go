@@ -0,0 +1,87 @@
+package mot
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// MatchObjectsAfterDrop is the same as MatchObjects, but tells tracker that given number of frames has been dropped
+// since the previous call (e.g. by overloaded pipeline). Tracks are moved forward with their velocities by that number of frames
+// before matching, so they don't lag behind. Number of compensated frames is limited by max no match.
+// No match counters stay untouched, since objects have not been observed on dropped frames.
+// If the frame is rejected, the number is kept for the next call
+func (tracker *SimpleTracker) MatchObjectsAfterDrop(dropped int, newObjects []*SimpleBlob) error {
+	tracker.pendingDrop = dropped
+	return tracker.matchObjects(context.Background(), time.Time{}, newObjects, nil)
+}
+
+// MatchObjectsWithResultAfterDrop is the same as MatchObjectsWithResult, but tells tracker that given number of frames has been dropped.
+// See MatchObjectsAfterDrop
+func (tracker *SimpleTracker) MatchObjectsWithResultAfterDrop(dropped int, newObjects []*SimpleBlob) (*MatchResult, error) {
+	tracker.pendingDrop = dropped
+	result := NewMatchResult()
+	err := tracker.matchObjects(context.Background(), time.Time{}, newObjects, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SetDropCompensation enables detection of dropped frames from timestamps: if time elapsed since the previous frame
+// (see MatchObjectsAt) spans several frame intervals (see SetFrameInterval), missing frames are compensated as in MatchObjectsAfterDrop.
+// It has no effect while frame interval is not set
+func (tracker *SimpleTracker) SetDropCompensation(enabled bool) {
+	tracker.dropCompensation = enabled
+}
+
+// GetDropCompensation returns whether dropped frames are detected from timestamps
+func (tracker *SimpleTracker) GetDropCompensation() bool {
+	return tracker.dropCompensation
+}
+
+// droppedFrames returns number of frames which have been dropped before the current one. Explicitly reported number takes precedence
+func (tracker *SimpleTracker) droppedFrames(explicit int) int {
+	if explicit > 0 {
+		return explicit
+	}
+	if !tracker.dropCompensation || tracker.frameInterval <= 0 || tracker.prevFrameTime.IsZero() {
+		return 0
+	}
+	frames := int(math.Round(float64(tracker.frameTime.Sub(tracker.prevFrameTime)) / float64(tracker.frameInterval)))
+	if frames <= 1 {
+		return 0
+	}
+	return frames - 1
+}
+
+// compensateDrop moves tracks forward by given number of dropped frames
+func (tracker *SimpleTracker) compensateDrop(dropped int) {
+	if dropped <= 0 {
+		return
+	}
+	tracker.counters.framesDropped += dropped
+	if dropped > tracker.maxNoMatch {
+		dropped = tracker.maxNoMatch
+	}
+	for _, object := range tracker.storage.tracks {
+		object.advance(dropped)
+	}
+}
+
+// MatchObjectsAfterDrop is the same as MatchObjects, but tells all tiles that given number of frames has been dropped.
+// See SimpleTracker.MatchObjectsAfterDrop
+func (tracker *TiledTracker) MatchObjectsAfterDrop(dropped int, newObjects []*SimpleBlob) error {
+	for _, tile := range tracker.tiles {
+		tile.pendingDrop = dropped
+	}
+	_, err := tracker.matchObjects(context.Background(), time.Time{}, newObjects)
+	return err
+}
+
+// SetDropCompensation enables detection of dropped frames from timestamps in all tiles. See SimpleTracker.SetDropCompensation
+func (tracker *TiledTracker) SetDropCompensation(enabled bool) {
+	for _, tile := range tracker.tiles {
+		tile.SetDropCompensation(enabled)
+	}
+}
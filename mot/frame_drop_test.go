@@ -0,0 +1,59 @@
+package mot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFrameDrop(t *testing.T) {
+	run := func(tracker *SimpleTracker, dropped int) {
+		for i := 0; i < 8; i++ {
+			err := tracker.MatchObjects([]*SimpleBlob{NewSimpleBlob(Rectangle{X: 10.0 + 10.0*float64(i), Y: 10.0, Width: 20.0, Height: 40.0})})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		// Frames 8, 9 and 10 are dropped
+		err := tracker.MatchObjectsAfterDrop(dropped, []*SimpleBlob{NewSimpleBlob(Rectangle{X: 120.0, Y: 10.0, Width: 20.0, Height: 40.0})})
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	lagging := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(5))
+	run(lagging, 0)
+	if len(lagging.Objects) != 2 {
+		t.Errorf("incorrect number of tracks without compensation: %v, expected: %v", len(lagging.Objects), 2)
+	}
+	compensated := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(5))
+	run(compensated, 3)
+	if len(compensated.Objects) != 1 {
+		t.Errorf("incorrect number of tracks with compensation: %v, expected: %v", len(compensated.Objects), 1)
+	}
+	if stats := compensated.Stats(); stats.FramesDropped != 3 {
+		t.Errorf("incorrect number of dropped frames: %v, expected: %v", stats.FramesDropped, 3)
+	}
+}
+
+func TestDropCompensation(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(5), WithDropCompensation(40*time.Millisecond))
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 8; i++ {
+		err := tracker.MatchObjectsAt(start.Add(time.Duration(i)*40*time.Millisecond), []*SimpleBlob{NewSimpleBlob(Rectangle{X: 10.0 + 10.0*float64(i), Y: 10.0, Width: 20.0, Height: 40.0})})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	err := tracker.MatchObjectsAt(start.Add(11*40*time.Millisecond), []*SimpleBlob{NewSimpleBlob(Rectangle{X: 120.0, Y: 10.0, Width: 20.0, Height: 40.0})})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(tracker.Objects) != 1 {
+		t.Errorf("incorrect number of tracks: %v, expected: %v", len(tracker.Objects), 1)
+	}
+	if stats := tracker.Stats(); stats.FramesDropped != 3 {
+		t.Errorf("incorrect number of dropped frames: %v, expected: %v", stats.FramesDropped, 3)
+	}
+}
@@ -0,0 +1,56 @@
+package mot
+
+import (
+	"context"
+	"time"
+)
+
+// FrameStamp identifies the frame which some data (e.g. track point) belongs to
+type FrameStamp struct {
+	// Index of the frame (starting from zero)
+	Frame int
+	// Timestamp of the frame (time of processing unless it has been provided via MatchObjectsAt)
+	Timestamp time.Time
+}
+
+// GetFrame returns index of the next frame, which is the number of frames processed so far
+func (tracker *SimpleTracker) GetFrame() int {
+	return tracker.counters.framesProcessed
+}
+
+// MatchObjectsAt is the same as MatchObjects, but frame is stamped with the given timestamp (e.g. capture time of video frame)
+// instead of the time of processing. Zero timestamp falls back to the time of processing
+func (tracker *SimpleTracker) MatchObjectsAt(timestamp time.Time, newObjects []*SimpleBlob) error {
+	return tracker.matchObjects(context.Background(), timestamp, newObjects, nil)
+}
+
+// MatchObjectsWithResultAt is the same as MatchObjectsWithResult, but frame is stamped with the given timestamp. See MatchObjectsAt
+func (tracker *SimpleTracker) MatchObjectsWithResultAt(timestamp time.Time, newObjects []*SimpleBlob) (*MatchResult, error) {
+	result := NewMatchResult()
+	err := tracker.matchObjects(context.Background(), timestamp, newObjects, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// frameStamp returns stamp of the frame which is being processed
+func (tracker *SimpleTracker) frameStamp() FrameStamp {
+	return FrameStamp{Frame: tracker.counters.framesProcessed, Timestamp: tracker.frameTime}
+}
+
+// GetFrame returns index of the next frame, which is the number of frames processed so far
+func (tracker *TiledTracker) GetFrame() int {
+	return tracker.tiles[0].GetFrame()
+}
+
+// MatchObjectsAt is the same as MatchObjects, but frame is stamped with the given timestamp. See SimpleTracker.MatchObjectsAt
+func (tracker *TiledTracker) MatchObjectsAt(timestamp time.Time, newObjects []*SimpleBlob) error {
+	_, err := tracker.matchObjects(context.Background(), timestamp, newObjects)
+	return err
+}
+
+// MatchObjectsWithResultAt is the same as MatchObjectsWithResult, but frame is stamped with the given timestamp. See SimpleTracker.MatchObjectsAt
+func (tracker *TiledTracker) MatchObjectsWithResultAt(timestamp time.Time, newObjects []*SimpleBlob) (*MatchResult, error) {
+	return tracker.matchObjects(context.Background(), timestamp, newObjects)
+}
@@ -0,0 +1,96 @@
+package mot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMatchObjectsAt(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10))
+	events := make([]Event, 0)
+	tracker.OnEvent(func(event Event) {
+		events = append(events, event)
+	})
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		timestamp := start.Add(time.Duration(i) * 40 * time.Millisecond)
+		result, err := tracker.MatchObjectsWithResultAt(timestamp, []*SimpleBlob{
+			NewSimpleBlob(Rectangle{X: 10.0 + float64(i), Y: 10.0, Width: 20.0, Height: 20.0}),
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.Frame != i || !result.Timestamp.Equal(timestamp) {
+			t.Errorf("incorrect result stamp on frame %d: %d %v, expected: %d %v", i, result.Frame, result.Timestamp, i, timestamp)
+		}
+	}
+	if tracker.GetFrame() != 3 {
+		t.Errorf("incorrect frame: %d, expected: %d", tracker.GetFrame(), 3)
+	}
+	if len(events) != 3 || events[2].Frame != 2 || !events[2].Timestamp.Equal(start.Add(80*time.Millisecond)) {
+		t.Errorf("incorrect events: %v", events)
+	}
+	tracks := tracker.GetTracks()
+	if len(tracks) != 1 {
+		t.Errorf("incorrect number of tracks: %d, expected: %d", len(tracks), 1)
+		return
+	}
+	stamps := tracks[0].GetTrackStamps()
+	if len(stamps) != len(tracks[0].GetTrack()) {
+		t.Errorf("incorrect number of track stamps: %d, expected: %d", len(stamps), len(tracks[0].GetTrack()))
+		return
+	}
+	for i, stamp := range stamps {
+		expected := start.Add(time.Duration(i) * 40 * time.Millisecond)
+		if stamp.Frame != i || !stamp.Timestamp.Equal(expected) {
+			t.Errorf("incorrect stamp of track point %d: %v, expected: %d %v", i, stamp, i, expected)
+		}
+	}
+}
+
+func TestAppendBounded(t *testing.T) {
+	values := make([]int, 0, 3)
+	for i := 0; i < 5; i++ {
+		values = appendBounded(values, i, 3)
+	}
+	if len(values) != 3 || values[0] != 2 || values[2] != 4 {
+		t.Errorf("incorrect bounded values: %v, expected: %v", values, []int{2, 3, 4})
+	}
+	if values = appendBounded(values, 5, 0); len(values) != 0 {
+		t.Errorf("incorrect bounded values for zero max length: %v, expected empty slice", values)
+	}
+}
+
+func TestTrackCreatedLastSeen(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10))
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	frames := [][]*SimpleBlob{
+		{NewSimpleBlob(Rectangle{X: 10.0, Y: 10.0, Width: 20.0, Height: 20.0})},
+		{NewSimpleBlob(Rectangle{X: 11.0, Y: 10.0, Width: 20.0, Height: 20.0})},
+		{},
+	}
+	for i, newObjects := range frames {
+		err := tracker.MatchObjectsAt(start.Add(time.Duration(i)*time.Second), newObjects)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	tracks := tracker.GetTracks()
+	if len(tracks) != 1 {
+		t.Errorf("incorrect number of tracks: %d, expected: %d", len(tracks), 1)
+		return
+	}
+	createdAt := tracks[0].GetCreatedAt()
+	if createdAt.Frame != 0 || !createdAt.Timestamp.Equal(start) {
+		t.Errorf("incorrect creation stamp: %v, expected: %d %v", createdAt, 0, start)
+	}
+	lastSeenAt := tracks[0].GetLastSeenAt()
+	if lastSeenAt.Frame != 1 || !lastSeenAt.Timestamp.Equal(start.Add(time.Second)) {
+		t.Errorf("incorrect last seen stamp: %v, expected: %d %v", lastSeenAt, 1, start.Add(time.Second))
+	}
+	if tracks[0].GetDwellTime() != time.Second {
+		t.Errorf("incorrect dwell time: %v, expected: %v", tracks[0].GetDwellTime(), time.Second)
+	}
+}
@@ -12,6 +12,10 @@ type Rectangle struct {
 	Height float64
 }
 
+// NewRect creates rectangle from top-left corner and size.
+//
+// Deprecated: parameters are misnamed (third one is the width and fourth one is the height) and no validation is done.
+// Use NewRectXYWH, NewRectFromCorners or NewRectFromCenter instead
 func NewRect(x, y, height, width float64) Rectangle {
 	return Rectangle{
 		X:      x,
@@ -21,6 +25,30 @@ func NewRect(x, y, height, width float64) Rectangle {
 	}
 }
 
+// NewRectXYWH creates rectangle from top-left corner and size. Returns ErrInvalidBBox for negative size or non-finite values
+func NewRectXYWH(x, y, width, height float64) (Rectangle, error) {
+	rect := Rectangle{
+		X:      x,
+		Y:      y,
+		Width:  width,
+		Height: height,
+	}
+	if err := validateRect(rect); err != nil {
+		return Rectangle{}, err
+	}
+	return rect, nil
+}
+
+// NewRectFromCorners creates rectangle from two opposite corners (in any order). Returns ErrInvalidBBox for non-finite values
+func NewRectFromCorners(x1, y1, x2, y2 float64) (Rectangle, error) {
+	return NewRectXYWH(math.Min(x1, x2), math.Min(y1, y2), math.Abs(x2-x1), math.Abs(y2-y1))
+}
+
+// NewRectFromCenter creates rectangle from its center and size. Returns ErrInvalidBBox for negative size or non-finite values
+func NewRectFromCenter(centerX, centerY, width, height float64) (Rectangle, error) {
+	return NewRectXYWH(centerX-width/2.0, centerY-height/2.0, width, height)
+}
+
 func NewRectFrom(rect image.Rectangle) Rectangle {
 	return Rectangle{
 		X:      float64(rect.Min.X),
@@ -30,6 +58,65 @@ func NewRectFrom(rect image.Rectangle) Rectangle {
 	}
 }
 
+// Area returns rectangle's area
+func (rect Rectangle) Area() float64 {
+	return rect.Width * rect.Height
+}
+
+// Center returns rectangle's center
+func (rect Rectangle) Center() Point {
+	return Point{
+		X: rect.X + rect.Width/2.0,
+		Y: rect.Y + rect.Height/2.0,
+	}
+}
+
+// Union returns the smallest rectangle which contains both rectangles
+func (rect Rectangle) Union(other Rectangle) Rectangle {
+	minX := math.Min(rect.X, other.X)
+	minY := math.Min(rect.Y, other.Y)
+	maxX := math.Max(rect.X+rect.Width, other.X+other.Width)
+	maxY := math.Max(rect.Y+rect.Height, other.Y+other.Height)
+	return Rectangle{X: minX, Y: minY, Width: maxX - minX, Height: maxY - minY}
+}
+
+// Intersect returns intersection of two rectangles. Returns empty rectangle if they do not overlap
+func (rect Rectangle) Intersect(other Rectangle) Rectangle {
+	minX := math.Max(rect.X, other.X)
+	minY := math.Max(rect.Y, other.Y)
+	maxX := math.Min(rect.X+rect.Width, other.X+other.Width)
+	maxY := math.Min(rect.Y+rect.Height, other.Y+other.Height)
+	if maxX <= minX || maxY <= minY {
+		return Rectangle{}
+	}
+	return Rectangle{X: minX, Y: minY, Width: maxX - minX, Height: maxY - minY}
+}
+
+// Contains checks if point is inside of rectangle (borders are included)
+func (rect Rectangle) Contains(pt Point) bool {
+	return pt.X >= rect.X && pt.X <= rect.X+rect.Width && pt.Y >= rect.Y && pt.Y <= rect.Y+rect.Height
+}
+
+// IsValid checks if rectangle has finite coordinates and non-negative size
+func (rect Rectangle) IsValid() bool {
+	return validateRect(rect) == nil
+}
+
+// Clamp cuts rectangle by frame bounds [0; frameWidth] x [0; frameHeight].
+// Rectangle which is completely outside of the frame becomes zero-size one lying on the nearest border
+func Clamp(rect Rectangle, frameWidth, frameHeight float64) Rectangle {
+	minX := math.Min(math.Max(rect.X, 0), frameWidth)
+	minY := math.Min(math.Max(rect.Y, 0), frameHeight)
+	maxX := math.Min(math.Max(rect.X+rect.Width, 0), frameWidth)
+	maxY := math.Min(math.Max(rect.Y+rect.Height, 0), frameHeight)
+	return Rectangle{X: minX, Y: minY, Width: maxX - minX, Height: maxY - minY}
+}
+
+// TrackBounds returns the smallest rectangle which contains all points of the track. Returns empty rectangle for empty track
+func TrackBounds(track []Point) Rectangle {
+	return Polygon{Points: track}.BoundingBox()
+}
+
 type Point struct {
 	X float64
 	Y float64
@@ -50,5 +137,5 @@ func NewPointFrom(point image.Point) Point {
 }
 
 func euclideanDistance(p1, p2 Point) float64 {
-	return math.Sqrt(math.Pow(float64(p1.X-p2.X), 2) + math.Pow(float64(p1.Y-p2.Y), 2))
+	return Distance(p1.X, p1.Y, p2.X, p2.Y)
 }
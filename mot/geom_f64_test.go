@@ -1,6 +1,7 @@
 package mot
 
 import (
+	"errors"
 	"math"
 	"testing"
 )
@@ -18,3 +19,79 @@ func TestEuclideanDistance(t *testing.T) {
 		t.Errorf("Wrong answer: %v, correct answer: %v", answer, correnctAnswer)
 	}
 }
+
+func TestRectangleMethods(t *testing.T) {
+	a := Rectangle{X: 0.0, Y: 0.0, Width: 10.0, Height: 20.0}
+	b := Rectangle{X: 5.0, Y: 10.0, Width: 10.0, Height: 20.0}
+	if a.Area() != 200.0 {
+		t.Errorf("incorrect area: %v, expected: %v", a.Area(), 200.0)
+	}
+	if a.Center() != (Point{X: 5.0, Y: 10.0}) {
+		t.Errorf("incorrect center: %v, expected: %v", a.Center(), Point{X: 5.0, Y: 10.0})
+	}
+	union := a.Union(b)
+	if union != (Rectangle{X: 0.0, Y: 0.0, Width: 15.0, Height: 30.0}) {
+		t.Errorf("incorrect union: %v, expected: %v", union, Rectangle{X: 0.0, Y: 0.0, Width: 15.0, Height: 30.0})
+	}
+	intersection := a.Intersect(b)
+	if intersection != (Rectangle{X: 5.0, Y: 10.0, Width: 5.0, Height: 10.0}) {
+		t.Errorf("incorrect intersection: %v, expected: %v", intersection, Rectangle{X: 5.0, Y: 10.0, Width: 5.0, Height: 10.0})
+	}
+	if empty := a.Intersect(Rectangle{X: 100.0, Y: 100.0, Width: 1.0, Height: 1.0}); empty.Area() != 0 {
+		t.Errorf("incorrect intersection of disjoint rectangles: %v, expected empty one", empty)
+	}
+	if !a.Contains(Point{X: 10.0, Y: 5.0}) || a.Contains(Point{X: 10.1, Y: 5.0}) {
+		t.Errorf("incorrect containment check for rectangle %v", a)
+	}
+	if !a.IsValid() || (Rectangle{Width: -1.0}).IsValid() || (Rectangle{X: math.NaN()}).IsValid() {
+		t.Errorf("incorrect validity check")
+	}
+}
+
+func TestRectConstructors(t *testing.T) {
+	expected := Rectangle{X: 10.0, Y: 20.0, Width: 30.0, Height: 40.0}
+	rect, err := NewRectXYWH(10.0, 20.0, 30.0, 40.0)
+	if err != nil || rect != expected {
+		t.Errorf("incorrect rectangle from XYWH: %v (err: %v), expected: %v", rect, err, expected)
+	}
+	rect, err = NewRectFromCorners(40.0, 60.0, 10.0, 20.0)
+	if err != nil || rect != expected {
+		t.Errorf("incorrect rectangle from corners: %v (err: %v), expected: %v", rect, err, expected)
+	}
+	rect, err = NewRectFromCenter(25.0, 40.0, 30.0, 40.0)
+	if err != nil || rect != expected {
+		t.Errorf("incorrect rectangle from center: %v (err: %v), expected: %v", rect, err, expected)
+	}
+	_, err = NewRectXYWH(10.0, 20.0, -30.0, 40.0)
+	if !errors.Is(err, ErrInvalidBBox) {
+		t.Errorf("incorrect error for negative width: %v, expected: %v", err, ErrInvalidBBox)
+	}
+	_, err = NewRectFromCenter(math.Inf(1), 20.0, 30.0, 40.0)
+	if !errors.Is(err, ErrInvalidBBox) {
+		t.Errorf("incorrect error for non-finite center: %v, expected: %v", err, ErrInvalidBBox)
+	}
+}
+
+func TestClamp(t *testing.T) {
+	rect := Clamp(Rectangle{X: -10.0, Y: 90.0, Width: 30.0, Height: 30.0}, 100.0, 100.0)
+	expected := Rectangle{X: 0.0, Y: 90.0, Width: 20.0, Height: 10.0}
+	if rect != expected {
+		t.Errorf("incorrect clamped rectangle: %v, expected: %v", rect, expected)
+	}
+	outside := Clamp(Rectangle{X: 150.0, Y: 10.0, Width: 30.0, Height: 30.0}, 100.0, 100.0)
+	if outside.Area() != 0 || outside.X != 100.0 {
+		t.Errorf("incorrect clamped rectangle outside of the frame: %v, expected zero-size one at the right border", outside)
+	}
+}
+
+func TestTrackBounds(t *testing.T) {
+	track := []Point{{X: 10, Y: 20}, {X: 15, Y: 5}, {X: 30, Y: 25}}
+	bounds := TrackBounds(track)
+	expected := Rectangle{X: 10, Y: 5, Width: 20, Height: 20}
+	if bounds != expected {
+		t.Errorf("incorrect track bounds: %v, expected: %v", bounds, expected)
+	}
+	if empty := TrackBounds(nil); empty != (Rectangle{}) {
+		t.Errorf("incorrect bounds of empty track: %v, expected: %v", empty, Rectangle{})
+	}
+}
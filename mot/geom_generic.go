@@ -0,0 +1,58 @@
+package mot
+
+import "math"
+
+// Float is the set of floating-point types which generic geometry helpers work with
+type Float interface {
+	~float32 | ~float64
+}
+
+// Distance returns euclidean distance between points (x1, y1) and (x2, y2)
+func Distance[T Float](x1, y1, x2, y2 T) T {
+	dx := float64(x1 - x2)
+	dy := float64(y1 - y2)
+	return T(math.Sqrt(dx*dx + dy*dy))
+}
+
+// IoUXYWH returns intersection over union of two rectangles given by top-left corner and size
+func IoUXYWH[T Float](ax, ay, aw, ah, bx, by, bw, bh T) T {
+	intersectionWidth := minFloat(ax+aw, bx+bw) - maxFloat(ax, bx)
+	intersectionHeight := minFloat(ay+ah, by+bh) - maxFloat(ay, by)
+	if intersectionWidth <= 0 || intersectionHeight <= 0 {
+		return 0
+	}
+	intersection := intersectionWidth * intersectionHeight
+	union := aw*ah + bw*bh - intersection
+	if union <= 0 {
+		return 0
+	}
+	return intersection / union
+}
+
+// IoAXYWH returns intersection over area of the first rectangle (rectangles are given by top-left corner and size)
+func IoAXYWH[T Float](ax, ay, aw, ah, bx, by, bw, bh T) T {
+	area := aw * ah
+	if area <= 0 {
+		return 0
+	}
+	intersectionWidth := minFloat(ax+aw, bx+bw) - maxFloat(ax, bx)
+	intersectionHeight := minFloat(ay+ah, by+bh) - maxFloat(ay, by)
+	if intersectionWidth <= 0 || intersectionHeight <= 0 {
+		return 0
+	}
+	return intersectionWidth * intersectionHeight / area
+}
+
+func minFloat[T Float](a, b T) T {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxFloat[T Float](a, b T) T {
+	if a > b {
+		return a
+	}
+	return b
+}
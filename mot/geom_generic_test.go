@@ -0,0 +1,20 @@
+package mot
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGenericGeometry(t *testing.T) {
+	if d := Distance(float32(0), float32(0), float32(3), float32(4)); d != 5 {
+		t.Errorf("incorrect float32 distance: %v, expected: %v", d, 5)
+	}
+	iou32 := IoUXYWH(float32(0), float32(0), float32(10), float32(10), float32(5), float32(0), float32(10), float32(10))
+	iou64 := IoU(Rectangle{X: 0, Y: 0, Width: 10, Height: 10}, Rectangle{X: 5, Y: 0, Width: 10, Height: 10})
+	if math.Abs(float64(iou32)-iou64) > 1e-6 {
+		t.Errorf("incorrect float32 IoU: %v, expected: %v", iou32, iou64)
+	}
+	if ioa := IoAXYWH(float32(0), float32(0), float32(10), float32(10), float32(5), float32(0), float32(10), float32(10)); ioa != 0.5 {
+		t.Errorf("incorrect float32 IoA: %v, expected: %v", ioa, 0.5)
+	}
+}
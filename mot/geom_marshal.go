@@ -0,0 +1,146 @@
+package mot
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Geometry types use compact representation:
+//
+//	Point:     JSON [x, y]                    text "x,y"
+//	Rectangle: JSON [x, y, width, height]     text "x,y,width,height"
+//	Segment:   JSON [[x1, y1], [x2, y2]]
+//	Polygon:   JSON [[x1, y1], [x2, y2], ...]
+
+// MarshalJSON encodes point as [x, y]
+func (pt Point) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]float64{pt.X, pt.Y})
+}
+
+// UnmarshalJSON decodes point from [x, y]
+func (pt *Point) UnmarshalJSON(data []byte) error {
+	var values []float64
+	if err := json.Unmarshal(data, &values); err != nil {
+		return errors.Wrap(err, "Can't decode point")
+	}
+	if len(values) != 2 {
+		return errors.Wrapf(ErrInvalidGeometry, "Point needs 2 values, got %d", len(values))
+	}
+	pt.X, pt.Y = values[0], values[1]
+	return nil
+}
+
+// MarshalText encodes point as "x,y"
+func (pt Point) MarshalText() ([]byte, error) {
+	return []byte(formatFloats(pt.X, pt.Y)), nil
+}
+
+// UnmarshalText decodes point from "x,y"
+func (pt *Point) UnmarshalText(text []byte) error {
+	values, err := parseFloats(string(text), 2)
+	if err != nil {
+		return errors.Wrap(err, "Can't decode point")
+	}
+	pt.X, pt.Y = values[0], values[1]
+	return nil
+}
+
+// MarshalJSON encodes rectangle as [x, y, width, height]
+func (rect Rectangle) MarshalJSON() ([]byte, error) {
+	return json.Marshal([4]float64{rect.X, rect.Y, rect.Width, rect.Height})
+}
+
+// UnmarshalJSON decodes rectangle from [x, y, width, height]
+func (rect *Rectangle) UnmarshalJSON(data []byte) error {
+	var values []float64
+	if err := json.Unmarshal(data, &values); err != nil {
+		return errors.Wrap(err, "Can't decode rectangle")
+	}
+	if len(values) != 4 {
+		return errors.Wrapf(ErrInvalidGeometry, "Rectangle needs 4 values, got %d", len(values))
+	}
+	rect.X, rect.Y, rect.Width, rect.Height = values[0], values[1], values[2], values[3]
+	return nil
+}
+
+// MarshalText encodes rectangle as "x,y,width,height"
+func (rect Rectangle) MarshalText() ([]byte, error) {
+	return []byte(formatFloats(rect.X, rect.Y, rect.Width, rect.Height)), nil
+}
+
+// UnmarshalText decodes rectangle from "x,y,width,height"
+func (rect *Rectangle) UnmarshalText(text []byte) error {
+	values, err := parseFloats(string(text), 4)
+	if err != nil {
+		return errors.Wrap(err, "Can't decode rectangle")
+	}
+	rect.X, rect.Y, rect.Width, rect.Height = values[0], values[1], values[2], values[3]
+	return nil
+}
+
+// MarshalJSON encodes segment as [[x1, y1], [x2, y2]]
+func (segment Segment) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]Point{segment.A, segment.B})
+}
+
+// UnmarshalJSON decodes segment from [[x1, y1], [x2, y2]]
+func (segment *Segment) UnmarshalJSON(data []byte) error {
+	var points []Point
+	if err := json.Unmarshal(data, &points); err != nil {
+		return errors.Wrap(err, "Can't decode segment")
+	}
+	if len(points) != 2 {
+		return errors.Wrapf(ErrInvalidGeometry, "Segment needs 2 points, got %d", len(points))
+	}
+	segment.A, segment.B = points[0], points[1]
+	return nil
+}
+
+// MarshalJSON encodes polygon as array of its vertices
+func (polygon Polygon) MarshalJSON() ([]byte, error) {
+	if polygon.Points == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(polygon.Points)
+}
+
+// UnmarshalJSON decodes polygon from array of its vertices. The same validation as in NewPolygon is done
+func (polygon *Polygon) UnmarshalJSON(data []byte) error {
+	var points []Point
+	if err := json.Unmarshal(data, &points); err != nil {
+		return errors.Wrap(err, "Can't decode polygon")
+	}
+	decoded, err := NewPolygon(points)
+	if err != nil {
+		return err
+	}
+	*polygon = decoded
+	return nil
+}
+
+func formatFloats(values ...float64) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = strconv.FormatFloat(value, 'g', -1, 64)
+	}
+	return strings.Join(parts, ",")
+}
+
+func parseFloats(text string, expected int) ([]float64, error) {
+	parts := strings.Split(text, ",")
+	if len(parts) != expected {
+		return nil, errors.Wrapf(ErrInvalidGeometry, "Expected %d comma-separated values, got %d", expected, len(parts))
+	}
+	values := make([]float64, expected)
+	for i, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, errors.Wrapf(ErrInvalidGeometry, "Value at index %d: %s", i, err.Error())
+		}
+		values[i] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,72 @@
+package mot
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestGeometryJSON(t *testing.T) {
+	type zone struct {
+		Area   Polygon   `json:"area"`
+		Line   Segment   `json:"line"`
+		Anchor Point     `json:"anchor"`
+		BBox   Rectangle `json:"bbox"`
+	}
+	polygon, err := NewPolygon([]Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	source := zone{
+		Area:   polygon,
+		Line:   NewSegment(Point{X: 1, Y: 2}, Point{X: 3, Y: 4}),
+		Anchor: Point{X: 1.5, Y: -2},
+		BBox:   Rectangle{X: 1, Y: 2, Width: 3, Height: 4},
+	}
+	data, err := json.Marshal(source)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expected := `{"area":[[0,0],[10,0],[10,10]],"line":[[1,2],[3,4]],"anchor":[1.5,-2],"bbox":[1,2,3,4]}`
+	if string(data) != expected {
+		t.Errorf("incorrect JSON: %s, expected: %s", data, expected)
+	}
+	var decoded zone
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if decoded.Line != source.Line || decoded.Anchor != source.Anchor || decoded.BBox != source.BBox || len(decoded.Area.Points) != 3 {
+		t.Errorf("incorrect decoded value: %+v, expected: %+v", decoded, source)
+	}
+	var rect Rectangle
+	err = json.Unmarshal([]byte(`[1, 2, 3]`), &rect)
+	if !errors.Is(err, ErrInvalidGeometry) {
+		t.Errorf("incorrect error: %v, expected: %v", err, ErrInvalidGeometry)
+	}
+}
+
+func TestGeometryText(t *testing.T) {
+	rect := Rectangle{X: 1.5, Y: 2, Width: 3, Height: 4}
+	text, err := rect.MarshalText()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if string(text) != "1.5,2,3,4" {
+		t.Errorf("incorrect text: %s, expected: %s", text, "1.5,2,3,4")
+	}
+	var decoded Rectangle
+	err = decoded.UnmarshalText([]byte("1.5, 2, 3, 4"))
+	if err != nil || decoded != rect {
+		t.Errorf("incorrect decoded rectangle: %v (err: %v), expected: %v", decoded, err, rect)
+	}
+	var pt Point
+	err = pt.UnmarshalText([]byte("1,x"))
+	if !errors.Is(err, ErrInvalidGeometry) {
+		t.Errorf("incorrect error: %v, expected: %v", err, ErrInvalidGeometry)
+	}
+}
@@ -0,0 +1,95 @@
+package mot
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// Polygon is simple (non self-intersecting) polygon. Vertices could be given in any direction, the last one is connected to the first one
+type Polygon struct {
+	Points []Point
+}
+
+// NewPolygon creates polygon from its vertices. Returns ErrInvalidGeometry if there are less than 3 vertices or some of them are not finite
+func NewPolygon(points []Point) (Polygon, error) {
+	if len(points) < 3 {
+		return Polygon{}, errors.Wrapf(ErrInvalidGeometry, "Polygon needs at least 3 points, got %d", len(points))
+	}
+	for i, pt := range points {
+		if !isFinite(pt.X) || !isFinite(pt.Y) {
+			return Polygon{}, errors.Wrapf(ErrInvalidGeometry, "Point at index %d is not finite (%f, %f)", i, pt.X, pt.Y)
+		}
+	}
+	return Polygon{Points: append([]Point{}, points...)}, nil
+}
+
+// Contains checks if point is inside of polygon via ray casting. Points lying exactly on the border could be classified either way
+func (polygon Polygon) Contains(pt Point) bool {
+	inside := false
+	n := len(polygon.Points)
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		pi, pj := polygon.Points[i], polygon.Points[j]
+		if (pi.Y > pt.Y) != (pj.Y > pt.Y) {
+			crossX := pi.X + (pt.Y-pi.Y)*(pj.X-pi.X)/(pj.Y-pi.Y)
+			if pt.X < crossX {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// signedArea returns area of polygon via shoelace formula. It is positive for counter-clockwise vertices (in Y-up coordinates)
+func (polygon Polygon) signedArea() float64 {
+	area := 0.0
+	n := len(polygon.Points)
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		area += polygon.Points[j].X*polygon.Points[i].Y - polygon.Points[i].X*polygon.Points[j].Y
+	}
+	return area / 2.0
+}
+
+// Area returns area of polygon
+func (polygon Polygon) Area() float64 {
+	return math.Abs(polygon.signedArea())
+}
+
+// Centroid returns center of mass of polygon. For degenerate polygons (zero area) it returns mean of vertices
+func (polygon Polygon) Centroid() Point {
+	n := len(polygon.Points)
+	if n == 0 {
+		return Point{}
+	}
+	area := polygon.signedArea()
+	if area == 0 {
+		mean := Point{}
+		for _, pt := range polygon.Points {
+			mean.X += pt.X
+			mean.Y += pt.Y
+		}
+		return Point{X: mean.X / float64(n), Y: mean.Y / float64(n)}
+	}
+	cx, cy := 0.0, 0.0
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		pi, pj := polygon.Points[i], polygon.Points[j]
+		cross := pj.X*pi.Y - pi.X*pj.Y
+		cx += (pj.X + pi.X) * cross
+		cy += (pj.Y + pi.Y) * cross
+	}
+	return Point{X: cx / (6.0 * area), Y: cy / (6.0 * area)}
+}
+
+// BoundingBox returns the smallest rectangle which contains polygon
+func (polygon Polygon) BoundingBox() Rectangle {
+	if len(polygon.Points) == 0 {
+		return Rectangle{}
+	}
+	minX, minY := polygon.Points[0].X, polygon.Points[0].Y
+	maxX, maxY := minX, minY
+	for _, pt := range polygon.Points[1:] {
+		minX, maxX = math.Min(minX, pt.X), math.Max(maxX, pt.X)
+		minY, maxY = math.Min(minY, pt.Y), math.Max(maxY, pt.Y)
+	}
+	return Rectangle{X: minX, Y: minY, Width: maxX - minX, Height: maxY - minY}
+}
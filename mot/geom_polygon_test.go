@@ -0,0 +1,37 @@
+package mot
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestPolygon(t *testing.T) {
+	// L-shaped polygon: 20x20 square without top-right 10x10 quarter
+	polygon, err := NewPolygon([]Point{{X: 0, Y: 0}, {X: 20, Y: 0}, {X: 20, Y: 10}, {X: 10, Y: 10}, {X: 10, Y: 20}, {X: 0, Y: 20}})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if math.Abs(polygon.Area()-300.0) > eps {
+		t.Errorf("incorrect area: %v, expected: %v", polygon.Area(), 300.0)
+	}
+	centroid := polygon.Centroid()
+	expected := Point{X: 25.0 / 3.0, Y: 25.0 / 3.0}
+	if math.Abs(centroid.X-expected.X) > eps || math.Abs(centroid.Y-expected.Y) > eps {
+		t.Errorf("incorrect centroid: %v, expected: %v", centroid, expected)
+	}
+	if !polygon.Contains(Point{X: 5, Y: 15}) || !polygon.Contains(Point{X: 15, Y: 5}) {
+		t.Errorf("points inside of polygon are not detected")
+	}
+	if polygon.Contains(Point{X: 15, Y: 15}) || polygon.Contains(Point{X: -1, Y: 5}) {
+		t.Errorf("points outside of polygon are detected as inside ones")
+	}
+	if bbox := polygon.BoundingBox(); bbox != (Rectangle{X: 0, Y: 0, Width: 20, Height: 20}) {
+		t.Errorf("incorrect bounding box: %v, expected: %v", bbox, Rectangle{X: 0, Y: 0, Width: 20, Height: 20})
+	}
+	_, err = NewPolygon([]Point{{X: 0, Y: 0}, {X: 1, Y: 1}})
+	if !errors.Is(err, ErrInvalidGeometry) {
+		t.Errorf("incorrect error: %v, expected: %v", err, ErrInvalidGeometry)
+	}
+}
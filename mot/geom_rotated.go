@@ -0,0 +1,105 @@
+package mot
+
+import "math"
+
+// RotatedRectangle is oriented bounding box
+type RotatedRectangle struct {
+	// Center of rectangle
+	Center Point
+	// Size of rectangle before rotation
+	Width  float64
+	Height float64
+	// Rotation angle (radians, counter-clockwise in Y-up coordinates)
+	Angle float64
+}
+
+// NewRotatedRect creates oriented bounding box from its center, size and rotation angle (radians)
+func NewRotatedRect(center Point, width, height, angle float64) RotatedRectangle {
+	return RotatedRectangle{
+		Center: center,
+		Width:  width,
+		Height: height,
+		Angle:  angle,
+	}
+}
+
+// Area returns rectangle's area
+func (rect RotatedRectangle) Area() float64 {
+	return rect.Width * rect.Height
+}
+
+// Polygon returns corners of rectangle (in counter-clockwise order for positive size)
+func (rect RotatedRectangle) Polygon() Polygon {
+	cos, sin := math.Cos(rect.Angle), math.Sin(rect.Angle)
+	halfWidth, halfHeight := rect.Width/2.0, rect.Height/2.0
+	offsets := [4][2]float64{{-halfWidth, -halfHeight}, {halfWidth, -halfHeight}, {halfWidth, halfHeight}, {-halfWidth, halfHeight}}
+	points := make([]Point, 0, 4)
+	for _, offset := range offsets {
+		points = append(points, Point{
+			X: rect.Center.X + offset[0]*cos - offset[1]*sin,
+			Y: rect.Center.Y + offset[0]*sin + offset[1]*cos,
+		})
+	}
+	return Polygon{Points: points}
+}
+
+// BoundingBox returns the smallest axis-aligned rectangle which contains rotated one
+func (rect RotatedRectangle) BoundingBox() Rectangle {
+	return rect.Polygon().BoundingBox()
+}
+
+// RotatedIoU returns intersection over union of two oriented bounding boxes
+func RotatedIoU(a, b RotatedRectangle) float64 {
+	areaA, areaB := a.Area(), b.Area()
+	if areaA <= 0 || areaB <= 0 {
+		return 0
+	}
+	intersection := clipConvex(a.Polygon(), b.Polygon()).Area()
+	union := areaA + areaB - intersection
+	if union <= 0 {
+		return 0
+	}
+	return intersection / union
+}
+
+// clipConvex returns intersection of subject polygon with convex clip polygon (Sutherland-Hodgman algorithm)
+func clipConvex(subject, clip Polygon) Polygon {
+	output := subject.Points
+	// Orientation of clip polygon defines which side of its edges is the inner one
+	orientation := 1
+	if clip.signedArea() < 0 {
+		orientation = -1
+	}
+	n := len(clip.Points)
+	for i := 0; i < n && len(output) > 0; i++ {
+		edge := Segment{A: clip.Points[i], B: clip.Points[(i+1)%n]}
+		input := output
+		output = make([]Point, 0, len(input)+1)
+		for j := range input {
+			current := input[j]
+			previous := input[(j+len(input)-1)%len(input)]
+			currentInside := edge.Side(current)*orientation >= 0
+			previousInside := edge.Side(previous)*orientation >= 0
+			if currentInside {
+				if !previousInside {
+					output = append(output, lineIntersection(previous, current, edge))
+				}
+				output = append(output, current)
+			} else if previousInside {
+				output = append(output, lineIntersection(previous, current, edge))
+			}
+		}
+	}
+	return Polygon{Points: output}
+}
+
+// lineIntersection returns intersection of segment PQ with the infinite line going through the edge
+func lineIntersection(p, q Point, edge Segment) Point {
+	cp := edge.cross(p)
+	cq := edge.cross(q)
+	if cp == cq {
+		return q
+	}
+	t := cp / (cp - cq)
+	return Point{X: p.X + t*(q.X-p.X), Y: p.Y + t*(q.Y-p.Y)}
+}
@@ -0,0 +1,29 @@
+package mot
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRotatedIoU(t *testing.T) {
+	a := NewRotatedRect(Point{X: 5, Y: 5}, 10, 10, 0)
+	b := NewRotatedRect(Point{X: 10, Y: 5}, 10, 10, 0)
+	// Without rotation it must be the same as for axis-aligned rectangles
+	expected := IoU(Rectangle{X: 0, Y: 0, Width: 10, Height: 10}, Rectangle{X: 5, Y: 0, Width: 10, Height: 10})
+	if iou := RotatedIoU(a, b); math.Abs(iou-expected) > eps {
+		t.Errorf("incorrect IoU of axis-aligned boxes: %v, expected: %v", iou, expected)
+	}
+	// Square rotated by 45 degrees around the same center: intersection is regular octagon
+	rotated := NewRotatedRect(Point{X: 5, Y: 5}, 10, 10, math.Pi/4)
+	octagon := 200.0 * (math.Sqrt2 - 1)
+	expected = octagon / (200.0 - octagon)
+	if iou := RotatedIoU(a, rotated); math.Abs(iou-expected) > eps {
+		t.Errorf("incorrect IoU of rotated boxes: %v, expected: %v", iou, expected)
+	}
+	if iou := RotatedIoU(a, NewRotatedRect(Point{X: 50, Y: 50}, 10, 10, 0.3)); iou != 0 {
+		t.Errorf("incorrect IoU of disjoint boxes: %v, expected: %v", iou, 0.0)
+	}
+	if iou := RotatedIoU(rotated, rotated); math.Abs(iou-1) > eps {
+		t.Errorf("incorrect IoU of the same box: %v, expected: %v", iou, 1.0)
+	}
+}
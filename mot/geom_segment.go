@@ -0,0 +1,120 @@
+package mot
+
+import "math"
+
+// Segment is line segment between two points
+type Segment struct {
+	A Point
+	B Point
+}
+
+// NewSegment creates segment between two points
+func NewSegment(a, b Point) Segment {
+	return Segment{
+		A: a,
+		B: b,
+	}
+}
+
+// Length returns length of segment
+func (segment Segment) Length() float64 {
+	return euclideanDistance(segment.A, segment.B)
+}
+
+// cross returns cross product of AB and AP vectors
+func (segment Segment) cross(pt Point) float64 {
+	return (segment.B.X-segment.A.X)*(pt.Y-segment.A.Y) - (segment.B.Y-segment.A.Y)*(pt.X-segment.A.X)
+}
+
+// Side returns on which side of the line going through the segment (from A to B) the point lies:
+// 1 - on the left side, -1 - on the right side, 0 - on the line itself.
+// Note: for image coordinates (Y axis pointing down) left and right are mirrored
+func (segment Segment) Side(pt Point) int {
+	cross := segment.cross(pt)
+	switch {
+	case cross > 0:
+		return 1
+	case cross < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// onSegment checks if point which is collinear with segment lies within its bounding box
+func (segment Segment) onSegment(pt Point) bool {
+	return pt.X >= math.Min(segment.A.X, segment.B.X) && pt.X <= math.Max(segment.A.X, segment.B.X) &&
+		pt.Y >= math.Min(segment.A.Y, segment.B.Y) && pt.Y <= math.Max(segment.A.Y, segment.B.Y)
+}
+
+// Intersects checks if two segments have at least one common point (touching and collinear overlapping are included)
+func (segment Segment) Intersects(other Segment) bool {
+	d1 := segment.Side(other.A)
+	d2 := segment.Side(other.B)
+	d3 := other.Side(segment.A)
+	d4 := other.Side(segment.B)
+	if d1*d2 < 0 && d3*d4 < 0 {
+		return true
+	}
+	return (d1 == 0 && segment.onSegment(other.A)) ||
+		(d2 == 0 && segment.onSegment(other.B)) ||
+		(d3 == 0 && other.onSegment(segment.A)) ||
+		(d4 == 0 && other.onSegment(segment.B))
+}
+
+// Intersection returns the common point of two segments. Returns false if segments do not intersect
+// or if they are parallel (collinear overlapping segments have no single common point)
+func (segment Segment) Intersection(other Segment) (Point, bool) {
+	rx, ry := segment.B.X-segment.A.X, segment.B.Y-segment.A.Y
+	sx, sy := other.B.X-other.A.X, other.B.Y-other.A.Y
+	denominator := rx*sy - ry*sx
+	if denominator == 0 {
+		return Point{}, false
+	}
+	qpx, qpy := other.A.X-segment.A.X, other.A.Y-segment.A.Y
+	t := (qpx*sy - qpy*sx) / denominator
+	u := (qpx*ry - qpy*rx) / denominator
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return Point{}, false
+	}
+	return Point{X: segment.A.X + t*rx, Y: segment.A.Y + t*ry}, true
+}
+
+// CrossingDirection is direction in which moving point crosses the segment
+type CrossingDirection uint16
+
+const (
+	// CrossingForward is crossing from the side where Segment.Side returns -1 to the side where it returns 1
+	CrossingForward = CrossingDirection(iota)
+	// CrossingBackward is crossing from the side where Segment.Side returns 1 to the side where it returns -1
+	CrossingBackward
+)
+
+// String returns name of crossing direction
+func (direction CrossingDirection) String() string {
+	switch direction {
+	case CrossingForward:
+		return "forward"
+	case CrossingBackward:
+		return "backward"
+	default:
+		return "unknown"
+	}
+}
+
+// Crossing checks if point moving from one position to another crosses the segment and returns direction of crossing.
+// Point which stops exactly on the segment has not crossed it yet: crossing is reported when it leaves the segment
+func (segment Segment) Crossing(from, to Point) (CrossingDirection, bool) {
+	sideFrom := segment.Side(from)
+	sideTo := segment.Side(to)
+	if sideTo == 0 || sideFrom == sideTo {
+		return 0, false
+	}
+	if !segment.Intersects(NewSegment(from, to)) {
+		return 0, false
+	}
+	if sideTo > 0 {
+		return CrossingForward, true
+	}
+	return CrossingBackward, true
+}
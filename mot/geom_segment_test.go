@@ -0,0 +1,39 @@
+package mot
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSegment(t *testing.T) {
+	horizontal := NewSegment(Point{X: 0, Y: 0}, Point{X: 10, Y: 0})
+	if horizontal.Side(Point{X: 5, Y: 5}) != 1 || horizontal.Side(Point{X: 5, Y: -5}) != -1 || horizontal.Side(Point{X: 20, Y: 0}) != 0 {
+		t.Errorf("incorrect side of points for segment %v", horizontal)
+	}
+	vertical := NewSegment(Point{X: 5, Y: -5}, Point{X: 5, Y: 5})
+	if !horizontal.Intersects(vertical) {
+		t.Errorf("crossing segments are not detected")
+	}
+	pt, ok := horizontal.Intersection(vertical)
+	if !ok || math.Abs(pt.X-5) > eps || math.Abs(pt.Y) > eps {
+		t.Errorf("incorrect intersection point: %v (found: %v), expected: %v", pt, ok, Point{X: 5, Y: 0})
+	}
+	touching := NewSegment(Point{X: 10, Y: 0}, Point{X: 10, Y: 10})
+	if !horizontal.Intersects(touching) {
+		t.Errorf("touching segments are not detected")
+	}
+	overlapping := NewSegment(Point{X: 5, Y: 0}, Point{X: 15, Y: 0})
+	if !horizontal.Intersects(overlapping) {
+		t.Errorf("collinear overlapping segments are not detected")
+	}
+	if _, ok := horizontal.Intersection(overlapping); ok {
+		t.Errorf("collinear segments should not have single intersection point")
+	}
+	distant := NewSegment(Point{X: 20, Y: -5}, Point{X: 20, Y: 5})
+	if horizontal.Intersects(distant) {
+		t.Errorf("distant segments are detected as intersecting ones")
+	}
+	if math.Abs(horizontal.Length()-10) > eps {
+		t.Errorf("incorrect length: %v, expected: %v", horizontal.Length(), 10.0)
+	}
+}
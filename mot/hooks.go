@@ -0,0 +1,25 @@
+package mot
+
+// FrameHooks is set of functions which are called around MatchObjects call and its stages.
+// Any of them could be nil
+type FrameHooks struct {
+	// BeforeFrame is called before matching. Returned detections are matched instead of the given ones,
+	// so hook could filter them (e.g. by region of interest) or modify them. Indices in MatchResult refer to the returned slice
+	BeforeFrame func(newObjects []*SimpleBlob) []*SimpleBlob
+	// AfterFrame is called when frame has been processed successfully. Result is nil unless MatchObjectsWithResult is used
+	AfterFrame func(result *MatchResult)
+	// BeforeStage is called when stage (StagePredict, StageCostMatrix, StageAssignment or StageCleanup) starts
+	BeforeStage func(stage string)
+	// AfterStage is called when stage finishes (even if matching has failed during it)
+	AfterStage func(stage string)
+}
+
+// AddHooks registers hooks. Hooks are called in the order of registration
+func (tracker *SimpleTracker) AddHooks(hooks FrameHooks) {
+	tracker.hooks = append(tracker.hooks, hooks)
+}
+
+// ClearHooks removes all registered hooks
+func (tracker *SimpleTracker) ClearHooks() {
+	tracker.hooks = nil
+}
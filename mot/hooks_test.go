@@ -0,0 +1,67 @@
+package mot
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFrameHooks(t *testing.T) {
+	calls := make([]string, 0)
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithHooks(FrameHooks{
+		BeforeFrame: func(newObjects []*SimpleBlob) []*SimpleBlob {
+			calls = append(calls, "before_frame")
+			// Keep detections inside of region of interest only
+			filtered := make([]*SimpleBlob, 0, len(newObjects))
+			for _, newObject := range newObjects {
+				if newObject.currentCenter.X < 100.0 {
+					filtered = append(filtered, newObject)
+				}
+			}
+			return filtered
+		},
+		AfterFrame: func(result *MatchResult) {
+			calls = append(calls, "after_frame")
+			if result == nil || len(result.Created) != 1 {
+				t.Errorf("incorrect result passed to AfterFrame: %v, expected single created track", result)
+			}
+		},
+		BeforeStage: func(stage string) {
+			calls = append(calls, "before_"+stage)
+		},
+		AfterStage: func(stage string) {
+			calls = append(calls, "after_"+stage)
+		},
+	}))
+	_, err := tracker.MatchObjectsWithResult([]*SimpleBlob{
+		NewSimpleBlob(Rectangle{X: 10.0, Y: 10.0, Width: 20.0, Height: 20.0}),
+		NewSimpleBlob(Rectangle{X: 200.0, Y: 10.0, Width: 20.0, Height: 20.0}),
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(tracker.Objects) != 1 {
+		t.Errorf("incorrect number of tracks: %d, expected: %d", len(tracker.Objects), 1)
+	}
+	expected := []string{
+		"before_frame",
+		"before_" + StagePredict, "after_" + StagePredict,
+		"before_" + StageCostMatrix, "after_" + StageCostMatrix,
+		"before_" + StageAssignment, "after_" + StageAssignment,
+		"before_" + StageCleanup, "after_" + StageCleanup,
+		"after_frame",
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("incorrect hooks calls: %v, expected: %v", calls, expected)
+	}
+	tracker.ClearHooks()
+	calls = calls[:0]
+	err = tracker.MatchObjects([]*SimpleBlob{NewSimpleBlob(Rectangle{X: 12.0, Y: 10.0, Width: 20.0, Height: 20.0})})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(calls) != 0 {
+		t.Errorf("incorrect hooks calls after ClearHooks: %v, expected none", calls)
+	}
+}
@@ -0,0 +1,88 @@
+package mot
+
+// trackMove is position change of track which has been matched on the current frame
+type trackMove struct {
+	track *SimpleBlob
+	from  Point
+	to    Point
+}
+
+// idSwitchMonitor flags suspicious associations
+type idSwitchMonitor struct {
+	// Max plausible distance (in track's diagonals) between detection and both the last and the predicted centers of track. Zero disables the check
+	maxJump float64
+	// Max distance (in track's diagonals) between new center of one track and old center of another one to consider them swapped. Zero disables the check
+	swapDistance float64
+	// Moves of tracks on the current frame (collected only if swap check is enabled)
+	moves []trackMove
+}
+
+// SetIDSwitchMonitor enables runtime heuristics which flag probable identity switches via events (for quality auditing, association itself is not changed):
+//   - EventSuspectedTeleport: visible track has been matched with detection which is further than maxJump diagonals of track
+//     from both its last center and its center predicted by Kalman filter
+//   - EventSuspectedSwap: two visible tracks have been matched with detections which are within swapDistance diagonals of each other's previous centers,
+//     i.e. tracks seem to have exchanged positions. Event is emitted for both tracks with RelatedTrackID pointing to the partner
+//
+// Zero disables corresponding check
+func (tracker *SimpleTracker) SetIDSwitchMonitor(maxJump, swapDistance float64) {
+	tracker.idMonitor.maxJump = maxJump
+	tracker.idMonitor.swapDistance = swapDistance
+	tracker.idMonitor.reset()
+}
+
+// GetIDSwitchMonitor returns thresholds of identity switch heuristics
+func (tracker *SimpleTracker) GetIDSwitchMonitor() (float64, float64) {
+	return tracker.idMonitor.maxJump, tracker.idMonitor.swapDistance
+}
+
+// reset forgets moves of the previous frame
+func (monitor *idSwitchMonitor) reset() {
+	for i := range monitor.moves {
+		monitor.moves[i].track = nil
+	}
+	monitor.moves = monitor.moves[:0]
+}
+
+// observeMove checks track which is about to be updated with detection for teleporting and remembers its move for swap check
+func (tracker *SimpleTracker) observeMove(object *SimpleBlob, newObject *SimpleBlob) {
+	if !object.confirmed {
+		return
+	}
+	monitor := &tracker.idMonitor
+	if monitor.maxJump > 0 {
+		jump := euclideanDistance(newObject.currentCenter, object.currentCenter)
+		jumpPredicted := euclideanDistance(newObject.currentCenter, object.predictedNextPosition)
+		if jump > monitor.maxJump*object.diagonal && jumpPredicted > monitor.maxJump*object.diagonal {
+			tracker.emit(EventSuspectedTeleport, object)
+		}
+	}
+	if monitor.swapDistance > 0 {
+		monitor.moves = append(monitor.moves, trackMove{track: object, from: object.currentCenter, to: newObject.currentCenter})
+	}
+}
+
+// checkSwaps emits events for pairs of tracks which seem to have exchanged positions on the current frame
+func (tracker *SimpleTracker) checkSwaps() {
+	monitor := &tracker.idMonitor
+	moves := monitor.moves
+	for i := 0; i < len(moves); i++ {
+		for j := i + 1; j < len(moves); j++ {
+			a, b := &moves[i], &moves[j]
+			if !crossedOver(a, b, monitor.swapDistance) || !crossedOver(b, a, monitor.swapDistance) {
+				continue
+			}
+			eventA := tracker.newEvent(EventSuspectedSwap, a.track)
+			eventA.RelatedTrackID = b.track.id
+			tracker.dispatch(eventA)
+			eventB := tracker.newEvent(EventSuspectedSwap, b.track)
+			eventB.RelatedTrackID = a.track.id
+			tracker.dispatch(eventB)
+		}
+	}
+}
+
+// crossedOver checks if move a has ended near the start of move b rather than near its own start
+func crossedOver(a, b *trackMove, swapDistance float64) bool {
+	toOther := euclideanDistance(a.to, b.from)
+	return toOther < swapDistance*a.track.diagonal && toOther < euclideanDistance(a.to, a.from)
+}
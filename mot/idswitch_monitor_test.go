@@ -0,0 +1,58 @@
+package mot
+
+import (
+	"testing"
+)
+
+func TestIDSwitchTeleport(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(60.0), WithIDSwitchMonitor(1.0, 0))
+	teleports := 0
+	tracker.OnEvent(func(event Event) {
+		if event.Type == EventSuspectedTeleport {
+			teleports++
+		}
+	})
+	positions := []float64{100.0, 102.0, 104.0, 150.0}
+	for _, x := range positions {
+		err := tracker.MatchObjects([]*SimpleBlob{NewSimpleBlob(Rectangle{X: x, Y: 100.0, Width: 20.0, Height: 20.0})})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if len(tracker.Objects) != 1 {
+		t.Errorf("incorrect number of tracks: %v, expected: %v", len(tracker.Objects), 1)
+	}
+	if teleports != 1 {
+		t.Errorf("incorrect number of teleport events: %v, expected: %v", teleports, 1)
+	}
+}
+
+func TestIDSwitchSwap(t *testing.T) {
+	tracker := NewSimpleTracker(WithIDSwitchMonitor(0, 1.0))
+	swaps := make(map[string]string)
+	tracker.OnEvent(func(event Event) {
+		if event.Type == EventSuspectedSwap {
+			swaps[event.TrackID.String()] = event.RelatedTrackID.String()
+		}
+	})
+	a := NewSimpleBlob(Rectangle{X: 100.0, Y: 100.0, Width: 20.0, Height: 20.0})
+	b := NewSimpleBlob(Rectangle{X: 125.0, Y: 100.0, Width: 20.0, Height: 20.0})
+	a.confirmed, b.confirmed = true, true
+	// Track a is matched with detection at the place of b and vice versa
+	tracker.observeMove(a, NewSimpleBlob(Rectangle{X: 124.0, Y: 100.0, Width: 20.0, Height: 20.0}))
+	tracker.observeMove(b, NewSimpleBlob(Rectangle{X: 101.0, Y: 100.0, Width: 20.0, Height: 20.0}))
+	tracker.checkSwaps()
+	if len(swaps) != 2 || swaps[a.GetID().String()] != b.GetID().String() || swaps[b.GetID().String()] != a.GetID().String() {
+		t.Errorf("incorrect swap events: %v", swaps)
+	}
+	// Tracks which keep their places are not swapped
+	swaps = make(map[string]string)
+	tracker.idMonitor.reset()
+	tracker.observeMove(a, NewSimpleBlob(Rectangle{X: 102.0, Y: 100.0, Width: 20.0, Height: 20.0}))
+	tracker.observeMove(b, NewSimpleBlob(Rectangle{X: 123.0, Y: 100.0, Width: 20.0, Height: 20.0}))
+	tracker.checkSwaps()
+	if len(swaps) != 0 {
+		t.Errorf("incorrect swap events: %v, expected none", swaps)
+	}
+}
@@ -0,0 +1,153 @@
+package mot
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// kalmanFilter is discrete Kalman filter for point moving in 2D with constant velocity: state vector is (x, y, vx, vy),
+// measurement is (x, y). It is the same model as github.com/LdDl/kalman-filter implements, but matrices are fixed-size arrays
+// (row-major order), so prediction and correction do not allocate
+type kalmanFilter struct {
+	// Transition matrix 4x4
+	a [16]float64
+	// Control matrix 4x2
+	b [8]float64
+	// Control input
+	u [2]float64
+	// Process noise covariance matrix 4x4
+	q [16]float64
+	// Measurement noise covariance matrix 2x2
+	r [4]float64
+	// Error covariance matrix 4x4
+	p [16]float64
+	// State vector
+	x [4]float64
+	// Single cycle time
+	dt float64
+}
+
+// newKalmanFilter creates filter for the point (x, y) with zero velocity
+//
+// dt - single cycle time
+// ux, uy - control input for X and Y
+// stdDevA - standard deviation of acceleration
+// stdDevMx, stdDevMy - standard deviation of measurement for X and Y
+func newKalmanFilter(dt, ux, uy, stdDevA, stdDevMx, stdDevMy, x, y float64) *kalmanFilter {
+	dt2 := dt * dt
+	dt3 := dt2 * dt
+	dt4 := dt3 * dt
+	varA := stdDevA * stdDevA
+	return &kalmanFilter{
+		a: [16]float64{
+			1, 0, dt, 0,
+			0, 1, 0, dt,
+			0, 0, 1, 0,
+			0, 0, 0, 1,
+		},
+		b: [8]float64{
+			0.5 * dt2, 0,
+			0, 0.5 * dt2,
+			dt, 0,
+			0, dt,
+		},
+		u: [2]float64{ux, uy},
+		q: [16]float64{
+			0.25 * dt4 * varA, 0, 0.5 * dt3 * varA, 0,
+			0, 0.25 * dt4 * varA, 0, 0.5 * dt3 * varA,
+			0.5 * dt3 * varA, 0, dt2 * varA, 0,
+			0, 0.5 * dt3 * varA, 0, dt2 * varA,
+		},
+		r: [4]float64{
+			stdDevMx * stdDevMx, 0,
+			0, stdDevMy * stdDevMy,
+		},
+		p: [16]float64{
+			1, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, 1, 0,
+			0, 0, 0, 1,
+		},
+		x:  [4]float64{x, y, 0, 0},
+		dt: dt,
+	}
+}
+
+// predict projects the state and the error covariance ahead: x = A*x + B*u, P = A*P*A^T + Q
+func (kf *kalmanFilter) predict() {
+	var x [4]float64
+	for i := 0; i < 4; i++ {
+		for k := 0; k < 4; k++ {
+			x[i] += kf.a[i*4+k] * kf.x[k]
+		}
+		x[i] += kf.b[i*2]*kf.u[0] + kf.b[i*2+1]*kf.u[1]
+	}
+	kf.x = x
+	var ap [16]float64
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			for k := 0; k < 4; k++ {
+				ap[i*4+j] += kf.a[i*4+k] * kf.p[k*4+j]
+			}
+		}
+	}
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			value := kf.q[i*4+j]
+			for k := 0; k < 4; k++ {
+				value += ap[i*4+k] * kf.a[j*4+k]
+			}
+			kf.p[i*4+j] = value
+		}
+	}
+}
+
+// update corrects the state and the error covariance with measurement (zx, zy).
+// Observation matrix selects position from the state, so products with it are taken by indexing
+func (kf *kalmanFilter) update(zx, zy float64) error {
+	// Innovation covariance S = H*P*H^T + R and its inverse
+	s00 := kf.p[0] + kf.r[0]
+	s01 := kf.p[1] + kf.r[1]
+	s10 := kf.p[4] + kf.r[2]
+	s11 := kf.p[5] + kf.r[3]
+	det := s00*s11 - s01*s10
+	if det == 0 || math.IsNaN(det) || math.IsInf(det, 0) {
+		return errors.Errorf("Innovation covariance is singular (determinant is %f)", det)
+	}
+	inv00, inv01 := s11/det, -s01/det
+	inv10, inv11 := -s10/det, s00/det
+	// Gain K = P*H^T*S^-1
+	var gain [8]float64
+	for i := 0; i < 4; i++ {
+		ph0, ph1 := kf.p[i*4], kf.p[i*4+1]
+		gain[i*2] = ph0*inv00 + ph1*inv10
+		gain[i*2+1] = ph0*inv01 + ph1*inv11
+	}
+	// x = x + K*(z - H*x)
+	r0 := zx - kf.x[0]
+	r1 := zy - kf.x[1]
+	for i := 0; i < 4; i++ {
+		kf.x[i] += gain[i*2]*r0 + gain[i*2+1]*r1
+	}
+	// P = (I - K*H)*P
+	var p [16]float64
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			p[i*4+j] = kf.p[i*4+j] - gain[i*2]*kf.p[j] - gain[i*2+1]*kf.p[4+j]
+		}
+	}
+	kf.p = p
+	return nil
+}
+
+// position returns X and Y of the state
+func (kf *kalmanFilter) position() (float64, float64) {
+	return kf.x[0], kf.x[1]
+}
+
+// setState sets state vector and error covariance matrix
+func (kf *kalmanFilter) setState(state [4]float64, covariance [16]float64) {
+	kf.x = state
+	kf.p = covariance
+}
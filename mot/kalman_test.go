@@ -0,0 +1,31 @@
+package mot
+
+import (
+	"math"
+	"testing"
+)
+
+func TestKalmanFilter(t *testing.T) {
+	kf := newKalmanFilter(1.0, 0.0, 0.0, 2.0, 0.1, 0.1, 10.0, 20.0)
+	// Object moves by (2, -1) per step
+	for step := 1; step <= 20; step++ {
+		kf.predict()
+		err := kf.update(10.0+2.0*float64(step), 20.0-float64(step))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	x, y := kf.position()
+	if math.Abs(x-50.0) > 0.1 || math.Abs(y-0.0) > 0.1 {
+		t.Errorf("incorrect position: (%v, %v), expected: (%v, %v)", x, y, 50.0, 0.0)
+	}
+	if math.Abs(kf.x[2]-2.0) > 0.1 || math.Abs(kf.x[3]+1.0) > 0.1 {
+		t.Errorf("incorrect velocity: (%v, %v), expected: (%v, %v)", kf.x[2], kf.x[3], 2.0, -1.0)
+	}
+	kf.setState(kf.x, [16]float64{})
+	kf.r = [4]float64{}
+	if err := kf.update(0.0, 0.0); err == nil {
+		t.Errorf("update with singular innovation covariance should fail")
+	}
+}
@@ -0,0 +1,95 @@
+package mot
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// kdTree is 2-d tree over tracks centers. It is built lazily on the first query after insertions
+type kdTree struct {
+	entries []kdEntry
+	built   bool
+}
+
+type kdEntry struct {
+	gridEntry
+	pt Point
+}
+
+func newKDTree() *kdTree {
+	return &kdTree{
+		entries: make([]kdEntry, 0),
+	}
+}
+
+// reset removes all entries from the tree
+func (tree *kdTree) reset() {
+	for i := range tree.entries {
+		tree.entries[i] = kdEntry{}
+	}
+	tree.entries = tree.entries[:0]
+	tree.built = false
+}
+
+// insert adds track to the tree
+func (tree *kdTree) insert(id uuid.UUID, object *SimpleBlob, pt Point) {
+	tree.entries = append(tree.entries, kdEntry{gridEntry: gridEntry{id: id, object: object}, pt: pt})
+	tree.built = false
+}
+
+// worthQuerying checks if tree search is cheaper than scanning all tracks
+func (tree *kdTree) worthQuerying(radius float64) bool {
+	return len(tree.entries) > smallFrameLimit
+}
+
+// query calls fn for each track which lies in square with given center and half-side equal to radius
+func (tree *kdTree) query(pt Point, radius float64, fn func(id uuid.UUID, object *SimpleBlob)) {
+	if !tree.built {
+		tree.build(0, len(tree.entries), 0)
+		tree.built = true
+	}
+	tree.search(0, len(tree.entries), 0, pt, radius, fn)
+}
+
+func kdCoord(pt Point, axis int) float64 {
+	if axis == 0 {
+		return pt.X
+	}
+	return pt.Y
+}
+
+// build sorts entries in-place so the median of each subrange splits it by the current axis
+func (tree *kdTree) build(lo, hi, depth int) {
+	if hi-lo <= 1 {
+		return
+	}
+	axis := depth % 2
+	part := tree.entries[lo:hi]
+	sort.Slice(part, func(i, j int) bool {
+		return kdCoord(part[i].pt, axis) < kdCoord(part[j].pt, axis)
+	})
+	mid := lo + (hi-lo)/2
+	tree.build(lo, mid, depth+1)
+	tree.build(mid+1, hi, depth+1)
+}
+
+func (tree *kdTree) search(lo, hi, depth int, pt Point, radius float64, fn func(id uuid.UUID, object *SimpleBlob)) {
+	if lo >= hi {
+		return
+	}
+	axis := depth % 2
+	mid := lo + (hi-lo)/2
+	entry := tree.entries[mid]
+	if entry.pt.X >= pt.X-radius && entry.pt.X <= pt.X+radius && entry.pt.Y >= pt.Y-radius && entry.pt.Y <= pt.Y+radius {
+		fn(entry.id, entry.object)
+	}
+	split := kdCoord(entry.pt, axis)
+	target := kdCoord(pt, axis)
+	if target-radius <= split {
+		tree.search(lo, mid, depth+1, pt, radius, fn)
+	}
+	if target+radius >= split {
+		tree.search(mid+1, hi, depth+1, pt, radius, fn)
+	}
+}
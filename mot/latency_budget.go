@@ -0,0 +1,69 @@
+package mot
+
+import (
+	"math"
+	"time"
+)
+
+// SetLatencyBudget sets max time (counted from the start of MatchObjects) which can be spent on evaluating association costs.
+// Once it is exceeded, the remaining detections are matched greedily in input order with the closest unreserved track by center distance
+// (cost function, appearance and distance mode are skipped for them), so the frame deadline is kept at the cost of accuracy.
+// Such frames are reported via TrackerStats.DegradedFrames. Zero disables the budget
+func (tracker *SimpleTracker) SetLatencyBudget(budget time.Duration) {
+	if budget < 0 {
+		budget = 0
+	}
+	tracker.latencyBudget = budget
+}
+
+// GetLatencyBudget returns max time which can be spent on evaluating association costs
+func (tracker *SimpleTracker) GetLatencyBudget() time.Duration {
+	return tracker.latencyBudget
+}
+
+// overBudget checks if latency budget of the current frame has been exceeded
+func (tracker *SimpleTracker) overBudget() bool {
+	return tracker.latencyBudget > 0 && time.Since(tracker.frameStart) > tracker.latencyBudget
+}
+
+// assignGreedy matches detections starting from given index with the closest unreserved tracks within gate.
+// Detections without such tracks are registered as new ones. Context is not checked here: some tracks have been updated already,
+// so the budget is the only deadline of the fallback
+func (tracker *SimpleTracker) assignGreedy(newObjects []*SimpleBlob, from int, result *MatchResult) error {
+	if from >= len(newObjects) {
+		return nil
+	}
+	tracker.counters.degradedFrames++
+	tracker.counters.lastFrameDegraded = true
+	reservedObjects := tracker.buffers.reservedObjects
+	for i := from; i < len(newObjects); i++ {
+		if tracker.buffers.rejected[i] {
+			continue
+		}
+		newObject := newObjects[i]
+		var closest *SimpleBlob
+		minDistance := math.MaxFloat64
+		for _, object := range tracker.storage.tracks {
+			if _, ok := reservedObjects[object.id]; ok {
+				continue
+			}
+			distance := newObject.DistanceTo(object)
+			if distance >= minDistance {
+				continue
+			}
+			if distance < newObject.diagonal*0.5 || distance < tracker.gateThreshold(object) {
+				minDistance = distance
+				closest = object
+			}
+		}
+		if closest == nil {
+			tracker.buffers.toRegister[i] = true
+			continue
+		}
+		err := tracker.matchTrack(closest, newObject, i, minDistance, result, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
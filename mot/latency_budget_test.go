@@ -0,0 +1,40 @@
+package mot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencyBudget(t *testing.T) {
+	// Budget is always exceeded, so every frame is matched greedily
+	tracker := NewSimpleTracker(WithLatencyBudget(time.Nanosecond), WithMinDistThreshold(15.0))
+	for i := 0; i < 5; i++ {
+		detections := []*SimpleBlob{
+			NewSimpleBlob(Rectangle{X: 10.0 + 5.0*float64(i), Y: 10.0, Width: 20.0, Height: 40.0}),
+			NewSimpleBlob(Rectangle{X: 200.0 - 5.0*float64(i), Y: 10.0, Width: 20.0, Height: 40.0}),
+		}
+		err := tracker.MatchObjects(detections)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if len(tracker.Objects) != 2 {
+		t.Errorf("incorrect number of tracks: %v, expected: %v", len(tracker.Objects), 2)
+	}
+	stats := tracker.Stats()
+	if stats.DegradedFrames != 5 || !stats.LastFrameDegraded {
+		t.Errorf("incorrect degradation stats: %v (last frame: %v), expected: %v", stats.DegradedFrames, stats.LastFrameDegraded, 5)
+	}
+
+	tracker.SetLatencyBudget(0)
+	err := tracker.MatchObjects([]*SimpleBlob{NewSimpleBlob(Rectangle{X: 35.0, Y: 10.0, Width: 20.0, Height: 40.0})})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	stats = tracker.Stats()
+	if stats.DegradedFrames != 5 || stats.LastFrameDegraded {
+		t.Errorf("incorrect degradation stats without budget: %v (last frame: %v), expected: %v", stats.DegradedFrames, stats.LastFrameDegraded, 5)
+	}
+}
@@ -0,0 +1,217 @@
+package mot
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// LensDistortion maps points between distorted image coordinates (as they are seen by the camera, e.g. fisheye one)
+// and rectified coordinates which tracking is done in
+type LensDistortion interface {
+	// Undistort maps point of the image to rectified coordinates
+	Undistort(pt Point) Point
+	// Distort maps rectified point back to the image
+	Distort(pt Point) Point
+}
+
+// CameraModel is pinhole camera intrinsics with Brown-Conrady distortion coefficients (the same as OpenCV uses:
+// radial K1, K2, K3 and tangential P1, P2). Rectified coordinates are pixels of ideal camera with the same intrinsics
+type CameraModel struct {
+	Fx float64
+	Fy float64
+	Cx float64
+	Cy float64
+	K1 float64
+	K2 float64
+	K3 float64
+	P1 float64
+	P2 float64
+}
+
+// Validate checks that focal lengths are non-zero and all parameters are finite
+func (camera CameraModel) Validate() error {
+	params := [...]float64{camera.Fx, camera.Fy, camera.Cx, camera.Cy, camera.K1, camera.K2, camera.K3, camera.P1, camera.P2}
+	for _, param := range params {
+		if !isFinite(param) {
+			return errors.Wrapf(ErrInvalidCameraModel, "Non-finite parameter in %+v", camera)
+		}
+	}
+	if camera.Fx == 0 || camera.Fy == 0 {
+		return errors.Wrapf(ErrInvalidCameraModel, "Zero focal length (%f, %f)", camera.Fx, camera.Fy)
+	}
+	return nil
+}
+
+// undistortIterations is number of fixed-point iterations which are done to invert distortion model
+const undistortIterations = 20
+
+// Distort maps rectified point back to the image
+func (camera CameraModel) Distort(pt Point) Point {
+	x := (pt.X - camera.Cx) / camera.Fx
+	y := (pt.Y - camera.Cy) / camera.Fy
+	dx, dy := camera.distortNormalized(x, y)
+	return Point{
+		X: dx*camera.Fx + camera.Cx,
+		Y: dy*camera.Fy + camera.Cy,
+	}
+}
+
+// Undistort maps point of the image to rectified coordinates. Distortion model has no closed-form inverse,
+// so it is inverted iteratively
+func (camera CameraModel) Undistort(pt Point) Point {
+	xd := (pt.X - camera.Cx) / camera.Fx
+	yd := (pt.Y - camera.Cy) / camera.Fy
+	x, y := xd, yd
+	for i := 0; i < undistortIterations; i++ {
+		r2 := x*x + y*y
+		radial := 1 + r2*(camera.K1+r2*(camera.K2+r2*camera.K3))
+		if radial == 0 {
+			break
+		}
+		deltaX := 2*camera.P1*x*y + camera.P2*(r2+2*x*x)
+		deltaY := camera.P1*(r2+2*y*y) + 2*camera.P2*x*y
+		x = (xd - deltaX) / radial
+		y = (yd - deltaY) / radial
+	}
+	return Point{
+		X: x*camera.Fx + camera.Cx,
+		Y: y*camera.Fy + camera.Cy,
+	}
+}
+
+// distortNormalized applies distortion to point in normalized camera coordinates
+func (camera CameraModel) distortNormalized(x, y float64) (float64, float64) {
+	r2 := x*x + y*y
+	radial := 1 + r2*(camera.K1+r2*(camera.K2+r2*camera.K3))
+	return x*radial + 2*camera.P1*x*y + camera.P2*(r2+2*x*x),
+		y*radial + camera.P1*(r2+2*y*y) + 2*camera.P2*x*y
+}
+
+// LensDistortionFuncs adapts custom mapping functions (e.g. lookup tables built by calibration tool) to LensDistortion.
+// Nil function does not change points
+type LensDistortionFuncs struct {
+	UndistortFunc func(pt Point) Point
+	DistortFunc   func(pt Point) Point
+}
+
+// Undistort maps point of the image to rectified coordinates
+func (funcs LensDistortionFuncs) Undistort(pt Point) Point {
+	if funcs.UndistortFunc == nil {
+		return pt
+	}
+	return funcs.UndistortFunc(pt)
+}
+
+// Distort maps rectified point back to the image
+func (funcs LensDistortionFuncs) Distort(pt Point) Point {
+	if funcs.DistortFunc == nil {
+		return pt
+	}
+	return funcs.DistortFunc(pt)
+}
+
+// pointMapper adapts single mapping function to Transform
+type pointMapper func(pt Point) Point
+
+// Apply maps single point
+func (mapper pointMapper) Apply(pt Point) Point {
+	return mapper(pt)
+}
+
+// ApplyRect maps corners of rectangle and returns their bounding box
+func (mapper pointMapper) ApplyRect(rect Rectangle) Rectangle {
+	return applyRect(mapper, rect)
+}
+
+// SetLensDistortion sets lens distortion of the camera. Detections passed to MatchObjects are undistorted in place before tracking,
+// so tracks (their centers, boxes and trajectories) are kept in rectified coordinates; use ImageBBox, ImageTrack and GetPredictions
+// to get them back in image coordinates. Distortion which has Validate method (e.g. CameraModel) is validated first
+// and is not set if it is invalid. Detections which get non-finite geometry after undistortion are rejected by MatchObjects
+// with ErrInvalidBBox. Nil disables correction
+func (tracker *SimpleTracker) SetLensDistortion(distortion LensDistortion) error {
+	if validator, ok := distortion.(interface{ Validate() error }); ok {
+		if err := validator.Validate(); err != nil {
+			return errors.Wrap(err, "Can't set lens distortion")
+		}
+	}
+	tracker.lensDistortion = distortion
+	return nil
+}
+
+// GetLensDistortion returns lens distortion of the camera
+func (tracker *SimpleTracker) GetLensDistortion() LensDistortion {
+	return tracker.lensDistortion
+}
+
+// ImageBBox returns blob's current bounding box in image coordinates
+func (tracker *SimpleTracker) ImageBBox(blob *SimpleBlob) Rectangle {
+	return tracker.distortBBox(blob.currentBBox)
+}
+
+// ImageTrack returns copy of blob's trajectory in image coordinates
+func (tracker *SimpleTracker) ImageTrack(blob *SimpleBlob) []Point {
+	track := make([]Point, len(blob.track))
+	for i, pt := range blob.track {
+		track[i] = tracker.distortPoint(pt)
+	}
+	return track
+}
+
+// distortPoint maps rectified point back to the image
+func (tracker *SimpleTracker) distortPoint(pt Point) Point {
+	if tracker.lensDistortion == nil {
+		return pt
+	}
+	return tracker.lensDistortion.Distort(pt)
+}
+
+// distortBBox maps rectified bounding box back to the image
+func (tracker *SimpleTracker) distortBBox(rect Rectangle) Rectangle {
+	if tracker.lensDistortion == nil {
+		return rect
+	}
+	return pointMapper(tracker.lensDistortion.Distort).ApplyRect(rect)
+}
+
+// undistortedGeometry is geometry of detection in rectified coordinates
+type undistortedGeometry struct {
+	center Point
+	bbox   Rectangle
+}
+
+// undistortDetections moves detections to rectified coordinates.
+// Detections are not changed if any of them gets non-finite geometry (e.g. iterative inversion of distortion model has diverged)
+func (tracker *SimpleTracker) undistortDetections(newObjects []*SimpleBlob) error {
+	if tracker.lensDistortion == nil {
+		return nil
+	}
+	undistort := pointMapper(tracker.lensDistortion.Undistort)
+	geometry := make([]undistortedGeometry, len(newObjects))
+	for i, newObject := range newObjects {
+		center := undistort.Apply(newObject.currentCenter)
+		bbox := undistort.ApplyRect(newObject.currentBBox)
+		if err := validateRect(bbox); err != nil {
+			return errors.Wrapf(err, "Blob at index %d after undistortion", i)
+		}
+		if !isFinite(center.X) || !isFinite(center.Y) {
+			return errors.Wrapf(ErrInvalidBBox, "Blob at index %d has non-finite center (%f, %f) after undistortion", i, center.X, center.Y)
+		}
+		geometry[i] = undistortedGeometry{center: center, bbox: bbox}
+	}
+	for i, newObject := range newObjects {
+		newObject.moveTo(geometry[i].center, geometry[i].bbox)
+	}
+	return nil
+}
+
+// moveTo replaces geometry of the fresh detection. Kalman filter is reset to the new center with zero velocity
+func (blob *SimpleBlob) moveTo(center Point, bbox Rectangle) {
+	blob.currentCenter = center
+	blob.currentBBox = bbox
+	blob.diagonal = math.Hypot(bbox.Width, bbox.Height)
+	if len(blob.track) > 0 {
+		blob.track[len(blob.track)-1] = center
+	}
+	blob.tracker.setState([4]float64{center.X, center.Y, 0, 0}, blob.tracker.p)
+}
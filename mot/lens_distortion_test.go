@@ -0,0 +1,102 @@
+package mot
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCameraModel(t *testing.T) {
+	camera := CameraModel{Fx: 500.0, Fy: 500.0, Cx: 320.0, Cy: 240.0, K1: -0.3, K2: 0.1, P1: 0.001, P2: -0.002}
+	points := []Point{{X: 320.0, Y: 240.0}, {X: 100.0, Y: 50.0}, {X: 600.0, Y: 400.0}}
+	for _, pt := range points {
+		restored := camera.Distort(camera.Undistort(pt))
+		if math.Abs(restored.X-pt.X) > 1e-6 || math.Abs(restored.Y-pt.Y) > 1e-6 {
+			t.Errorf("incorrect round trip for point %v: %v", pt, restored)
+		}
+	}
+	// Barrel distortion squeezes image, so undistortion moves corner points away from the principal point
+	undistorted := camera.Undistort(Point{X: 100.0, Y: 50.0})
+	if undistorted.X >= 100.0 || undistorted.Y >= 50.0 {
+		t.Errorf("incorrect undistorted point: %v", undistorted)
+	}
+}
+
+func TestLensDistortion(t *testing.T) {
+	shift := LensDistortionFuncs{
+		UndistortFunc: func(pt Point) Point { return Point{X: pt.X - 100.0, Y: pt.Y} },
+		DistortFunc:   func(pt Point) Point { return Point{X: pt.X + 100.0, Y: pt.Y} },
+	}
+	tracker := NewSimpleTracker(WithLensDistortion(shift))
+	bbox := Rectangle{X: 200.0, Y: 50.0, Width: 20.0, Height: 40.0}
+	err := tracker.MatchObjects([]*SimpleBlob{NewSimpleBlob(bbox)})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	track := tracker.GetTracks()[0]
+	if track.GetCenter() != (Point{X: 110.0, Y: 70.0}) {
+		t.Errorf("incorrect rectified center: %v, expected: %v", track.GetCenter(), Point{X: 110.0, Y: 70.0})
+	}
+	if track.GetTrack()[0] != track.GetCenter() {
+		t.Errorf("incorrect rectified trajectory: %v", track.GetTrack())
+	}
+	if imageBBox := tracker.ImageBBox(track); imageBBox != bbox {
+		t.Errorf("incorrect image bbox: %v, expected: %v", imageBBox, bbox)
+	}
+	if imageTrack := tracker.ImageTrack(track); imageTrack[0] != (Point{X: 210.0, Y: 70.0}) {
+		t.Errorf("incorrect image trajectory: %v", imageTrack)
+	}
+	err = tracker.MatchObjects([]*SimpleBlob{NewSimpleBlob(Rectangle{X: 202.0, Y: 50.0, Width: 20.0, Height: 40.0})})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(tracker.Objects) != 1 {
+		t.Errorf("incorrect number of tracks: %v, expected: %v", len(tracker.Objects), 1)
+	}
+	predictions := tracker.GetPredictions()
+	if len(predictions) != 1 || predictions[0].BBox.X < 150.0 {
+		t.Errorf("prediction should be in image coordinates: %v", predictions)
+	}
+}
+
+func TestInvalidLensDistortion(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(5))
+	err := tracker.SetLensDistortion(CameraModel{Fx: 0.0, Fy: 500.0, Cx: 320.0, Cy: 240.0})
+	if !errors.Is(err, ErrInvalidCameraModel) {
+		t.Errorf("incorrect error for zero focal length: %v, expected: %v", err, ErrInvalidCameraModel)
+	}
+	if tracker.GetLensDistortion() != nil {
+		t.Errorf("invalid camera model should not be set")
+	}
+	err = tracker.SetLensDistortion(LensDistortionFuncs{UndistortFunc: func(pt Point) Point {
+		return Point{X: math.NaN(), Y: pt.Y}
+	}})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	detection := NewSimpleBlob(Rectangle{X: 10.0, Y: 10.0, Width: 20.0, Height: 20.0})
+	err = tracker.MatchObjects([]*SimpleBlob{detection})
+	if !errors.Is(err, ErrInvalidBBox) {
+		t.Errorf("incorrect error for non-finite undistorted geometry: %v, expected: %v", err, ErrInvalidBBox)
+	}
+	if len(tracker.GetTracks()) != 0 {
+		t.Errorf("incorrect number of tracks: %d, expected: %d", len(tracker.GetTracks()), 0)
+	}
+	if center := detection.GetCenter(); center.X != 20.0 {
+		t.Errorf("rejected detection should not be changed: %v", center)
+	}
+}
+
+func TestInvalidLensDistortionOption(t *testing.T) {
+	defer func() {
+		err, ok := recover().(error)
+		if !ok || !errors.Is(err, ErrInvalidCameraModel) {
+			t.Errorf("incorrect panic for invalid camera model: %v, expected: %v", err, ErrInvalidCameraModel)
+		}
+	}()
+	NewSimpleTracker(WithLensDistortion(CameraModel{Fx: 0.0, Fy: 500.0, Cx: 320.0, Cy: 240.0}))
+	t.Errorf("invalid camera model should not be accepted by option")
+}
@@ -0,0 +1,72 @@
+package mot
+
+// Letterbox describes how original frame has been resized (and padded) to the detector's input:
+//
+//	model = original * scale + pad
+type Letterbox struct {
+	ScaleX float64
+	ScaleY float64
+	PadX   float64
+	PadY   float64
+}
+
+// NewLetterbox creates conversion for frame of size frameWidth x frameHeight which has been resized to fit into
+// modelWidth x modelHeight input keeping aspect ratio and then padded to the center (the common preprocessing of YOLO-like detectors)
+func NewLetterbox(frameWidth, frameHeight, modelWidth, modelHeight float64) Letterbox {
+	scale := modelWidth / frameWidth
+	if modelHeight/frameHeight < scale {
+		scale = modelHeight / frameHeight
+	}
+	return Letterbox{
+		ScaleX: scale,
+		ScaleY: scale,
+		PadX:   (modelWidth - frameWidth*scale) / 2.0,
+		PadY:   (modelHeight - frameHeight*scale) / 2.0,
+	}
+}
+
+// NewResize creates conversion for frame of size frameWidth x frameHeight which has been stretched to modelWidth x modelHeight input without padding
+func NewResize(frameWidth, frameHeight, modelWidth, modelHeight float64) Letterbox {
+	return Letterbox{
+		ScaleX: modelWidth / frameWidth,
+		ScaleY: modelHeight / frameHeight,
+	}
+}
+
+// PointToOriginal converts point from the detector's input space to the original frame space
+func (letterbox Letterbox) PointToOriginal(pt Point) Point {
+	return Point{
+		X: (pt.X - letterbox.PadX) / letterbox.ScaleX,
+		Y: (pt.Y - letterbox.PadY) / letterbox.ScaleY,
+	}
+}
+
+// PointToModel converts point from the original frame space to the detector's input space
+func (letterbox Letterbox) PointToModel(pt Point) Point {
+	return Point{
+		X: pt.X*letterbox.ScaleX + letterbox.PadX,
+		Y: pt.Y*letterbox.ScaleY + letterbox.PadY,
+	}
+}
+
+// ToOriginal converts bounding box from the detector's input space to the original frame space
+func (letterbox Letterbox) ToOriginal(rect Rectangle) Rectangle {
+	topLeft := letterbox.PointToOriginal(Point{X: rect.X, Y: rect.Y})
+	return Rectangle{
+		X:      topLeft.X,
+		Y:      topLeft.Y,
+		Width:  rect.Width / letterbox.ScaleX,
+		Height: rect.Height / letterbox.ScaleY,
+	}
+}
+
+// ToModel converts bounding box from the original frame space to the detector's input space
+func (letterbox Letterbox) ToModel(rect Rectangle) Rectangle {
+	topLeft := letterbox.PointToModel(Point{X: rect.X, Y: rect.Y})
+	return Rectangle{
+		X:      topLeft.X,
+		Y:      topLeft.Y,
+		Width:  rect.Width * letterbox.ScaleX,
+		Height: rect.Height * letterbox.ScaleY,
+	}
+}
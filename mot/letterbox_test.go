@@ -0,0 +1,29 @@
+package mot
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLetterbox(t *testing.T) {
+	// 1280x720 frame is fitted into 640x640 input: scale is 0.5 and vertical padding is 140
+	letterbox := NewLetterbox(1280.0, 720.0, 640.0, 640.0)
+	if letterbox.ScaleX != 0.5 || letterbox.ScaleY != 0.5 || letterbox.PadX != 0 || letterbox.PadY != 140.0 {
+		t.Errorf("incorrect letterbox: %+v, expected scale %v and padding (%v, %v)", letterbox, 0.5, 0.0, 140.0)
+	}
+	model := Rectangle{X: 100.0, Y: 150.0, Width: 50.0, Height: 20.0}
+	original := letterbox.ToOriginal(model)
+	expected := Rectangle{X: 200.0, Y: 20.0, Width: 100.0, Height: 40.0}
+	if original != expected {
+		t.Errorf("incorrect box in original space: %v, expected: %v", original, expected)
+	}
+	back := letterbox.ToModel(original)
+	if math.Abs(back.X-model.X) > eps || math.Abs(back.Y-model.Y) > eps || math.Abs(back.Width-model.Width) > eps || math.Abs(back.Height-model.Height) > eps {
+		t.Errorf("incorrect box in model space: %v, expected: %v", back, model)
+	}
+	resize := NewResize(1280.0, 720.0, 640.0, 640.0)
+	pt := resize.PointToOriginal(Point{X: 320.0, Y: 320.0})
+	if math.Abs(pt.X-640.0) > eps || math.Abs(pt.Y-360.0) > eps {
+		t.Errorf("incorrect point in original space: %v, expected: %v", pt, Point{X: 640.0, Y: 360.0})
+	}
+}
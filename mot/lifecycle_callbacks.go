@@ -0,0 +1,44 @@
+package mot
+
+// trackCallbacks holds user functions which are called on track lifecycle changes
+type trackCallbacks struct {
+	created func(track *SimpleBlob)
+	lost    func(track *SimpleBlob)
+	removed func(track *SimpleBlob)
+}
+
+// SetOnTrackCreated sets function which is called during MatchObjects when new track becomes visible
+// (right after registration or after confirmation if activation delay is used). Nil disables the callback
+func (tracker *SimpleTracker) SetOnTrackCreated(fn func(track *SimpleBlob)) {
+	tracker.callbacks.created = fn
+}
+
+// SetOnTrackLost sets function which is called during MatchObjects when visible track has not been matched
+// after being matched on the previous frame. Nil disables the callback
+func (tracker *SimpleTracker) SetOnTrackLost(fn func(track *SimpleBlob)) {
+	tracker.callbacks.lost = fn
+}
+
+// SetOnTrackRemoved sets function which is called during MatchObjects when visible track is removed from tracker
+// (it has not been found for too long or it has been evicted). Nil disables the callback
+func (tracker *SimpleTracker) SetOnTrackRemoved(fn func(track *SimpleBlob)) {
+	tracker.callbacks.removed = fn
+}
+
+func (callbacks *trackCallbacks) onCreated(track *SimpleBlob) {
+	if callbacks.created != nil {
+		callbacks.created(track)
+	}
+}
+
+func (callbacks *trackCallbacks) onLost(track *SimpleBlob) {
+	if callbacks.lost != nil && track.confirmed {
+		callbacks.lost(track)
+	}
+}
+
+func (callbacks *trackCallbacks) onRemoved(track *SimpleBlob) {
+	if callbacks.removed != nil && track.confirmed {
+		callbacks.removed(track)
+	}
+}
@@ -0,0 +1,35 @@
+package mot
+
+import (
+	"testing"
+)
+
+func TestLifecycleCallbacks(t *testing.T) {
+	created, lost, removed := 0, 0, 0
+	tracker := NewSimpleTracker(
+		WithMinDistThreshold(15.0),
+		WithMaxNoMatch(2),
+		WithOnTrackCreated(func(track *SimpleBlob) { created++ }),
+		WithOnTrackLost(func(track *SimpleBlob) { lost++ }),
+		WithOnTrackRemoved(func(track *SimpleBlob) { removed++ }),
+	)
+	frames := [][]*SimpleBlob{
+		{NewSimpleBlob(Rectangle{X: 10.0, Y: 10.0, Width: 20.0, Height: 20.0})},
+		{NewSimpleBlob(Rectangle{X: 11.0, Y: 10.0, Width: 20.0, Height: 20.0})},
+		{},
+		{},
+		{},
+	}
+	expected := [][3]int{{1, 0, 0}, {1, 0, 0}, {1, 1, 0}, {1, 1, 1}, {1, 1, 1}}
+	for i, frame := range frames {
+		err := tracker.MatchObjects(frame)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		got := [3]int{created, lost, removed}
+		if got != expected[i] {
+			t.Errorf("incorrect created/lost/removed counts on frame %d: %v, expected: %v", i, got, expected[i])
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package mot
+
+import (
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// AddTrack seeds tracker with the given blob (e.g. object selected by user or handed off from another system).
+// Blob becomes confirmed track immediately and is matched with detections on subsequent frames as any other track.
+// Track is stamped with the frame which has been processed last (see MatchObjectsAt), so it shares the time base with other tracks
+func (tracker *SimpleTracker) AddTrack(blob *SimpleBlob) error {
+	if err := validateBlobs([]*SimpleBlob{blob}); err != nil {
+		return errors.Wrap(err, "Can't add track")
+	}
+	return tracker.addTrackWithID(blob.id, blob)
+}
+
+// AddTrackWithID is the same as AddTrack, but blob gets the given identifier
+func (tracker *SimpleTracker) AddTrackWithID(id uuid.UUID, blob *SimpleBlob) error {
+	if err := validateBlobs([]*SimpleBlob{blob}); err != nil {
+		return errors.Wrap(err, "Can't add track")
+	}
+	return tracker.addTrackWithID(id, blob)
+}
+
+// addTrackWithID seeds tracker with the blob which has been validated already
+func (tracker *SimpleTracker) addTrackWithID(id uuid.UUID, blob *SimpleBlob) error {
+	if _, exists := tracker.storage.get(id); exists {
+		return errors.Wrapf(ErrDuplicateTrack, "Can't add track %s", id.String())
+	}
+	blob.id = id
+	blob.noMatchTimes = 0
+	blob.consecutiveMatches = 1
+	blob.confirmed = false
+	blob.createdAt = tracker.frameStamp()
+	blob.lastSeenAt = blob.createdAt
+	blob.stampLastPoint(blob.createdAt)
+	tracker.recordBBox(blob)
+	tracker.pushFeature(blob)
+	tracker.addTrack(blob)
+	tracker.counters.tracksCreated++
+	// Seeded track is not subject to confirmation delay
+	tracker.publishTrack(blob)
+	return nil
+}
+
+// AddTrack seeds the tile which contains blob's center with the given blob. See SimpleTracker.AddTrack
+func (tracker *TiledTracker) AddTrack(blob *SimpleBlob) error {
+	if err := validateBlobs([]*SimpleBlob{blob}); err != nil {
+		return errors.Wrap(err, "Can't add track")
+	}
+	return tracker.addTrackWithID(blob.id, blob)
+}
+
+// AddTrackWithID is the same as AddTrack, but blob gets the given identifier
+func (tracker *TiledTracker) AddTrackWithID(id uuid.UUID, blob *SimpleBlob) error {
+	if err := validateBlobs([]*SimpleBlob{blob}); err != nil {
+		return errors.Wrap(err, "Can't add track")
+	}
+	return tracker.addTrackWithID(id, blob)
+}
+
+// addTrackWithID seeds the tile with the blob which has been validated already
+func (tracker *TiledTracker) addTrackWithID(id uuid.UUID, blob *SimpleBlob) error {
+	if _, exists := tracker.GetTrack(id); exists {
+		return errors.Wrapf(ErrDuplicateTrack, "Can't add track %s", id.String())
+	}
+	return tracker.tiles[tracker.tileOf(blob.currentCenter)].addTrackWithID(id, blob)
+}
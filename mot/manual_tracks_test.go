@@ -0,0 +1,62 @@
+package mot
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestAddTrack(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10), WithMinConsecutiveMatches(3))
+	created := 0
+	tracker.SetOnTrackCreated(func(track *SimpleBlob) {
+		created++
+	})
+	seededID := uuid.New()
+	err := tracker.AddTrackWithID(seededID, NewSimpleBlob(Rectangle{X: 10.0, Y: 10.0, Width: 20.0, Height: 20.0}))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, ok := tracker.Objects[seededID]; !ok || created != 1 {
+		t.Errorf("seeded track %s should be visible immediately (created callbacks: %d)", seededID, created)
+	}
+	result, err := tracker.MatchObjectsWithResult([]*SimpleBlob{NewSimpleBlob(Rectangle{X: 12.0, Y: 10.0, Width: 20.0, Height: 20.0})})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result.Matched) != 1 || result.Matched[0].TrackID != seededID {
+		t.Errorf("incorrect matches: %v, expected seeded track %s to be matched", result.Matched, seededID)
+	}
+	err = tracker.AddTrackWithID(seededID, NewSimpleBlob(Rectangle{X: 100.0, Y: 10.0, Width: 20.0, Height: 20.0}))
+	if !errors.Is(err, ErrDuplicateTrack) {
+		t.Errorf("incorrect error for duplicate track: %v, expected: %v", err, ErrDuplicateTrack)
+	}
+	err = tracker.AddTrack(nil)
+	if !errors.Is(err, ErrInvalidBBox) {
+		t.Errorf("incorrect error for nil blob: %v, expected: %v", err, ErrInvalidBBox)
+	}
+}
+
+func TestAddTrackStamp(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10))
+	// Offline processing: frames are stamped with video time
+	videoTime := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	err := tracker.MatchObjectsAt(videoTime, []*SimpleBlob{NewSimpleBlob(Rectangle{X: 10.0, Y: 10.0, Width: 20.0, Height: 20.0})})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	seeded := NewSimpleBlob(Rectangle{X: 200.0, Y: 10.0, Width: 20.0, Height: 20.0})
+	err = tracker.AddTrack(seeded)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !seeded.GetCreatedAt().Timestamp.Equal(videoTime) {
+		t.Errorf("incorrect creation time of seeded track: %v, expected: %v", seeded.GetCreatedAt().Timestamp, videoTime)
+	}
+}
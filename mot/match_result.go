@@ -0,0 +1,55 @@
+package mot
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MatchedTrack describes existing track which has been updated by some detection
+type MatchedTrack struct {
+	// Identifier of existing track
+	TrackID uuid.UUID
+	// Index of detection in the input slice
+	DetectionIndex int
+	// Association cost (for SimpleTracker it is distance between centers unless custom cost function is set)
+	Score float64
+}
+
+// CreatedTrack describes track which has been registered from unmatched detection
+type CreatedTrack struct {
+	// Identifier of new track
+	TrackID uuid.UUID
+	// Index of detection in the input slice
+	DetectionIndex int
+	// Whether identifier of recently removed track has been reused. See SimpleTracker.SetResurrectionWindow and SimpleTracker.SetReIDRecovery
+	Resurrected bool
+}
+
+// MatchResult is per-frame association report
+type MatchResult struct {
+	// Index of the frame (starting from zero)
+	Frame int
+	// Timestamp of the frame (time of processing unless it has been provided explicitly)
+	Timestamp time.Time
+	// Existing tracks which have been matched with detections
+	Matched []MatchedTrack
+	// New tracks which have been created from unmatched detections
+	Created []CreatedTrack
+	// Existing tracks which have not been matched with any detection
+	Unmatched []uuid.UUID
+	// Indices of detections which have been ignored by tracker's filters (e.g. too small bounding box)
+	Rejected []int
+	// Durations of the frame's stages
+	Timings FrameTimings
+}
+
+// NewMatchResult creates empty MatchResult
+func NewMatchResult() *MatchResult {
+	return &MatchResult{
+		Matched:   make([]MatchedTrack, 0),
+		Created:   make([]CreatedTrack, 0),
+		Unmatched: make([]uuid.UUID, 0),
+		Rejected:  make([]int, 0),
+	}
+}
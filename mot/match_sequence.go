@@ -0,0 +1,94 @@
+package mot
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// MatchSequence processes batch of buffered frames in one call and returns association report for each of them.
+// Frames are associated in the given order since each of them depends on the state left by the previous one,
+// but validation and moving of detections to rectified coordinates (see SetLensDistortion) are done for the next frames
+// in background while the current one is associated. Frames must not share blobs. On error results of the frames processed
+// before the failed one are returned, while detections of the next frames could have been prepared already.
+// If hooks with BeforeFrame are registered, frames are not prepared in advance since hooks could replace detections
+func (tracker *SimpleTracker) MatchSequence(frames [][]*SimpleBlob) ([]*MatchResult, error) {
+	for _, hooks := range tracker.hooks {
+		if hooks.BeforeFrame != nil {
+			return pipelineFrames(frames, func(newObjects []*SimpleBlob) ([]*SimpleBlob, error) {
+				return newObjects, nil
+			}, tracker.MatchObjectsWithResult)
+		}
+	}
+	return pipelineFrames(frames, tracker.prepareFrame, func(newObjects []*SimpleBlob) (*MatchResult, error) {
+		result := NewMatchResult()
+		err := tracker.matchFrame(context.Background(), time.Time{}, newObjects, result, true)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	})
+}
+
+// prepareFrame does the part of MatchObjects which depends on the frame only: validation, undistortion and normalization of features
+func (tracker *SimpleTracker) prepareFrame(newObjects []*SimpleBlob) ([]*SimpleBlob, error) {
+	if err := validateBlobs(newObjects); err != nil {
+		return nil, err
+	}
+	if err := tracker.undistortDetections(newObjects); err != nil {
+		return nil, err
+	}
+	tracker.normalizeFeatures(newObjects)
+	return newObjects, nil
+}
+
+// MatchSequence processes batch of buffered frames in one call and returns association report for each of them.
+// Frames are associated in the given order since each of them depends on the state left by the previous one,
+// but validation and distribution of detections between tiles are done for the next frames in background while the current one is associated
+func (tracker *TiledTracker) MatchSequence(frames [][]*SimpleBlob) ([]*MatchResult, error) {
+	return pipelineFrames(frames, tracker.partition, func(frame *tiledFrame) (*MatchResult, error) {
+		return tracker.matchPartitioned(context.Background(), time.Time{}, frame)
+	})
+}
+
+// preparedFrame is result of frame preparation
+type preparedFrame[P any] struct {
+	frame P
+	err   error
+}
+
+// pipelineFrames prepares frames in background goroutine (at most two frames ahead) and processes them in order.
+// It stops at the first error of either step and returns results of the frames processed before it
+func pipelineFrames[P any](frames [][]*SimpleBlob, prepare func(newObjects []*SimpleBlob) (P, error), process func(frame P) (*MatchResult, error)) ([]*MatchResult, error) {
+	results := make([]*MatchResult, 0, len(frames))
+	ahead := make(chan preparedFrame[P], 1)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer close(ahead)
+		for _, newObjects := range frames {
+			frame, err := prepare(newObjects)
+			select {
+			case ahead <- preparedFrame[P]{frame: frame, err: err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	for prepared := range ahead {
+		i := len(results)
+		if prepared.err != nil {
+			return results, errors.Wrapf(prepared.err, "Can't match objects on frame %d", i)
+		}
+		result, err := process(prepared.frame)
+		if err != nil {
+			return results, errors.Wrapf(err, "Can't match objects on frame %d", i)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,74 @@
+package mot
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMatchSequencePipelined(t *testing.T) {
+	camera := CameraModel{Fx: 500.0, Fy: 500.0, Cx: 320.0, Cy: 240.0, K1: -0.3, K2: 0.1}
+	makeFrames := func() [][]*SimpleBlob {
+		frames := make([][]*SimpleBlob, 20)
+		for i := range frames {
+			frames[i] = []*SimpleBlob{
+				NewSimpleBlob(NewRect(100.0+float64(i)*2.0, 100.0, 20.0, 20.0)),
+				NewSimpleBlob(NewRect(400.0, 300.0-float64(i)*2.0, 20.0, 20.0)),
+			}
+		}
+		return frames
+	}
+	sequential := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(5), WithLensDistortion(camera))
+	for _, frame := range makeFrames() {
+		err := sequential.MatchObjects(frame)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	pipelined := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(5), WithLensDistortion(camera))
+	results, err := pipelined.MatchSequence(makeFrames())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(results) != 20 {
+		t.Errorf("incorrect number of results: %d, expected: %d", len(results), 20)
+		return
+	}
+	expected := sequential.GetTracks()
+	tracks := pipelined.GetTracks()
+	if len(tracks) != len(expected) {
+		t.Errorf("incorrect number of tracks: %d, expected: %d", len(tracks), len(expected))
+		return
+	}
+	for i := range tracks {
+		got, want := tracks[i].GetCenter(), expected[i].GetCenter()
+		if math.Abs(got.X-want.X) > eps || math.Abs(got.Y-want.Y) > eps {
+			t.Errorf("incorrect center of track %d: %v, expected: %v", i, got, want)
+		}
+	}
+}
+
+func TestMatchSequenceInvalidFrame(t *testing.T) {
+	for _, tracker := range []interface {
+		MatchSequence(frames [][]*SimpleBlob) ([]*MatchResult, error)
+	}{
+		NewNewSimpleTracker(15.0, 5),
+		NewTiledTracker(400.0, 200.0, 2, 1, 15.0, 5),
+	} {
+		frames := [][]*SimpleBlob{
+			{NewSimpleBlob(NewRect(10.0, 10.0, 20.0, 20.0))},
+			{NewSimpleBlob(NewRect(11.0, 10.0, 20.0, 20.0))},
+			{nil},
+			{NewSimpleBlob(NewRect(12.0, 10.0, 20.0, 20.0))},
+		}
+		results, err := tracker.MatchSequence(frames)
+		if !errors.Is(err, ErrInvalidBBox) {
+			t.Errorf("incorrect error: %v, expected: %v", err, ErrInvalidBBox)
+		}
+		if len(results) != 2 {
+			t.Errorf("incorrect number of results: %d, expected: %d", len(results), 2)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package mot
+
+import (
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// trackEntry is single point of track history with its stamp and (optional) bounding box
+type trackEntry struct {
+	point   Point
+	stamp   FrameStamp
+	bbox    Rectangle
+	hasBBox bool
+}
+
+// historyEntries returns blob's history as list of entries. Bounding boxes history is aligned with the end of track
+func (blob *SimpleBlob) historyEntries() []trackEntry {
+	entries := make([]trackEntry, len(blob.track))
+	bboxOffset := len(blob.track) - len(blob.bboxHistory)
+	for i, pt := range blob.track {
+		entries[i].point = pt
+		if i < len(blob.trackStamps) {
+			entries[i].stamp = blob.trackStamps[i]
+		}
+		if i >= bboxOffset {
+			entries[i].bbox = blob.bboxHistory[i-bboxOffset]
+			entries[i].hasBBox = true
+		}
+	}
+	return entries
+}
+
+// setHistory replaces blob's history with the given entries (keeping the latest ones if there are more than max track length).
+// Bounding boxes are kept for the tail of entries which all have them
+func (blob *SimpleBlob) setHistory(entries []trackEntry) {
+	if len(entries) > blob.maxTrackLen {
+		entries = entries[len(entries)-blob.maxTrackLen:]
+	}
+	blob.track = blob.track[:0]
+	blob.trackStamps = blob.trackStamps[:0]
+	blob.bboxHistory = blob.bboxHistory[:0]
+	for _, entry := range entries {
+		blob.track = append(blob.track, entry.point)
+		blob.trackStamps = append(blob.trackStamps, entry.stamp)
+		if !entry.hasBBox {
+			blob.bboxHistory = blob.bboxHistory[:0]
+			continue
+		}
+		blob.bboxHistory = append(blob.bboxHistory, entry.bbox)
+	}
+}
+
+// MergeTracks merges track dropID into track keepID (e.g. when tracker has broken single object into two tracks).
+// Histories are merged by frames (points of keepID win on the same frame), galleries of appearance features are joined
+// within feature budget. If dropID has been seen later,
+// keepID takes over its current position, Kalman filter and appearance feature. Track dropID is removed from tracker
+func (tracker *SimpleTracker) MergeTracks(keepID, dropID uuid.UUID) error {
+	if keepID == dropID {
+		return errors.Wrapf(ErrDuplicateTrack, "Can't merge track %s with itself", keepID.String())
+	}
+	keep, ok := tracker.storage.get(keepID)
+	if !ok {
+		return errors.Wrapf(ErrUnknownTrack, "Can't merge into track %s", keepID.String())
+	}
+	if _, ok := tracker.storage.get(dropID); !ok {
+		return errors.Wrapf(ErrUnknownTrack, "Can't merge track %s", dropID.String())
+	}
+	drop, _ := tracker.detachTrack(dropID)
+	tracker.detachFromArena(keep)
+
+	keepEntries := keep.historyEntries()
+	dropEntries := drop.historyEntries()
+	merged := make([]trackEntry, 0, len(keepEntries)+len(dropEntries))
+	i, j := 0, 0
+	for i < len(keepEntries) || j < len(dropEntries) {
+		switch {
+		case j >= len(dropEntries):
+			merged = append(merged, keepEntries[i])
+			i++
+		case i >= len(keepEntries):
+			merged = append(merged, dropEntries[j])
+			j++
+		case keepEntries[i].stamp.Frame < dropEntries[j].stamp.Frame:
+			merged = append(merged, keepEntries[i])
+			i++
+		case keepEntries[i].stamp.Frame > dropEntries[j].stamp.Frame:
+			merged = append(merged, dropEntries[j])
+			j++
+		default:
+			merged = append(merged, keepEntries[i])
+			i++
+			j++
+		}
+	}
+	if drop.lastSeenAt.Frame > keep.lastSeenAt.Frame {
+		keep.gallery = mergeGalleries(keep.gallery, drop.gallery, tracker.featureBudget)
+		keep.currentBBox = drop.currentBBox
+		keep.currentCenter = drop.currentCenter
+		keep.predictedNextPosition = drop.predictedNextPosition
+		keep.diagonal = drop.diagonal
+		keep.tracker = drop.tracker
+		keep.confidence = drop.confidence
+		keep.active = drop.active
+		keep.noMatchTimes = drop.noMatchTimes
+		keep.consecutiveMatches = drop.consecutiveMatches
+		keep.lastSeenAt = drop.lastSeenAt
+		keep.feature = drop.feature
+	} else {
+		keep.gallery = mergeGalleries(drop.gallery, keep.gallery, tracker.featureBudget)
+	}
+	if drop.createdAt.Frame < keep.createdAt.Frame {
+		keep.createdAt = drop.createdAt
+	}
+	keep.matches += drop.matches
+	keep.pinned = keep.pinned || drop.pinned
+	keep.setHistory(merged)
+	tracker.attachToArena(keep)
+	if !keep.confirmed && drop.confirmed {
+		tracker.publishTrack(keep)
+	}
+
+	tracker.counters.tracksRemoved++
+	tracker.callbacks.onRemoved(drop)
+	if drop.confirmed {
+		tracker.emit(EventTrackRemoved, drop)
+	}
+	tracker.releaseDisplayID(drop)
+	return nil
+}
+
+// SplitTrack splits track id into two ones: points starting from frame fromFrame are moved to the new track,
+// which takes over current position, Kalman filter and appearance features. Class and pin are kept by both tracks. The old track keeps earlier points and is treated as lost since then.
+// Returns identifier of the new track
+func (tracker *SimpleTracker) SplitTrack(id uuid.UUID, fromFrame int) (uuid.UUID, error) {
+	blob, ok := tracker.storage.get(id)
+	if !ok {
+		return uuid.Nil, errors.Wrapf(ErrUnknownTrack, "Can't split track %s", id.String())
+	}
+	entries := blob.historyEntries()
+	splitIdx := len(entries)
+	for i, entry := range entries {
+		if entry.stamp.Frame >= fromFrame {
+			splitIdx = i
+			break
+		}
+	}
+	if splitIdx == 0 || splitIdx == len(entries) {
+		return uuid.Nil, errors.Wrapf(ErrInvalidSplit, "Track %s has no points before or after frame %d", id.String(), fromFrame)
+	}
+	tracker.detachFromArena(blob)
+	head, tail := entries[:splitIdx], entries[splitIdx:]
+	lastHead := head[len(head)-1]
+
+	// New track continues the object, so it gets current state
+	split := &SimpleBlob{
+		id:                    uuid.New(),
+		currentBBox:           blob.currentBBox,
+		currentCenter:         blob.currentCenter,
+		predictedNextPosition: blob.predictedNextPosition,
+		track:                 make([]Point, 0, len(tail)),
+		maxTrackLen:           blob.maxTrackLen,
+		active:                blob.active,
+		noMatchTimes:          blob.noMatchTimes,
+		diagonal:              blob.diagonal,
+		tracker:               blob.tracker,
+		confidence:            blob.confidence,
+		consecutiveMatches:    blob.consecutiveMatches,
+		matches:               len(tail),
+		createdAt:             tail[0].stamp,
+		lastSeenAt:            blob.lastSeenAt,
+		feature:               blob.feature,
+		gallery:               append([][]float32(nil), blob.gallery...),
+		class:                 blob.class,
+		pinned:                blob.pinned,
+	}
+	split.setHistory(tail)
+
+	// Old track stops at its last point before split
+	bbox := blob.currentBBox
+	if lastHead.hasBBox {
+		bbox = lastHead.bbox
+	} else {
+		bbox.X += lastHead.point.X - blob.currentCenter.X
+		bbox.Y += lastHead.point.Y - blob.currentCenter.Y
+	}
+	restarted := NewSimpleBlobWithCenterTime(lastHead.point, bbox, blob.tracker.dt)
+	blob.tracker = restarted.tracker
+	blob.currentBBox = bbox
+	blob.currentCenter = lastHead.point
+	blob.predictedNextPosition = lastHead.point
+	blob.active = false
+	blob.consecutiveMatches = 0
+	blob.matches -= len(tail)
+	if blob.matches < 1 {
+		blob.matches = 1
+	}
+	blob.lastSeenAt = lastHead.stamp
+	blob.noMatchTimes = tracker.counters.framesProcessed - 1 - lastHead.stamp.Frame
+	if blob.noMatchTimes < 0 {
+		blob.noMatchTimes = 0
+	}
+	blob.setHistory(head)
+	tracker.attachToArena(blob)
+
+	tracker.addTrack(split)
+	tracker.counters.tracksCreated++
+	if blob.confirmed {
+		tracker.publishTrack(split)
+	}
+	return split.id, nil
+}
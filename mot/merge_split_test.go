@@ -0,0 +1,200 @@
+package mot
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// buildTwoTracks feeds tracker with object which jumps too far on frame jumpFrame, so it is broken into two tracks
+func buildTwoTracks(tracker *SimpleTracker, frames, jumpFrame int) (uuid.UUID, uuid.UUID, error) {
+	var firstID, secondID uuid.UUID
+	for i := 0; i < frames; i++ {
+		x := 10.0 + 2.0*float64(i)
+		if i >= jumpFrame {
+			x += 100.0
+		}
+		result, err := tracker.MatchObjectsWithResult([]*SimpleBlob{NewSimpleBlob(Rectangle{X: x, Y: 10.0, Width: 20.0, Height: 20.0})})
+		if err != nil {
+			return firstID, secondID, err
+		}
+		if i == 0 {
+			firstID = result.Created[0].TrackID
+		}
+		if i == jumpFrame {
+			secondID = result.Created[0].TrackID
+		}
+	}
+	return firstID, secondID, nil
+}
+
+func TestMergeTracks(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10))
+	firstID, secondID, err := buildTwoTracks(tracker, 6, 3)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = tracker.MergeTracks(firstID, secondID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(tracker.Objects) != 1 {
+		t.Errorf("incorrect number of tracks after merge: %d, expected: %d", len(tracker.Objects), 1)
+		return
+	}
+	merged := tracker.Objects[firstID]
+	if merged == nil {
+		t.Errorf("track %s should be kept after merge", firstID)
+		return
+	}
+	stamps := merged.GetTrackStamps()
+	if len(stamps) != 6 {
+		t.Errorf("incorrect merged track length: %d, expected: %d", len(stamps), 6)
+		return
+	}
+	for i, stamp := range stamps {
+		if stamp.Frame != i {
+			t.Errorf("incorrect frame of merged point %d: %d, expected: %d", i, stamp.Frame, i)
+		}
+	}
+	if merged.GetLastSeenAt().Frame != 5 || merged.GetCreatedAt().Frame != 0 || merged.GetMatches() != 6 {
+		t.Errorf("incorrect merged track state: created %v, last seen %v, matches %d", merged.GetCreatedAt(), merged.GetLastSeenAt(), merged.GetMatches())
+	}
+	if err = tracker.MergeTracks(firstID, secondID); !errors.Is(err, ErrUnknownTrack) {
+		t.Errorf("incorrect error for merged track: %v, expected: %v", err, ErrUnknownTrack)
+	}
+	// Merged track should keep matching detections
+	result, err := tracker.MatchObjectsWithResult([]*SimpleBlob{NewSimpleBlob(Rectangle{X: 122.0, Y: 10.0, Width: 20.0, Height: 20.0})})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result.Matched) != 1 || result.Matched[0].TrackID != firstID {
+		t.Errorf("incorrect matches after merge: %v, expected track %s", result.Matched, firstID)
+	}
+}
+
+func TestSplitTrack(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10))
+	var trackID uuid.UUID
+	for i := 0; i < 6; i++ {
+		result, err := tracker.MatchObjectsWithResult([]*SimpleBlob{NewSimpleBlob(Rectangle{X: 10.0 + 2.0*float64(i), Y: 10.0, Width: 20.0, Height: 20.0})})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if i == 0 {
+			trackID = result.Created[0].TrackID
+		}
+	}
+	tracker.Objects[trackID].SetClass("car")
+	if err := tracker.PinTrack(trackID); err != nil {
+		t.Error(err)
+		return
+	}
+	splitID, err := tracker.SplitTrack(trackID, 4)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(tracker.Objects) != 2 {
+		t.Errorf("incorrect number of tracks after split: %d, expected: %d", len(tracker.Objects), 2)
+		return
+	}
+	original, split := tracker.Objects[trackID], tracker.Objects[splitID]
+	if len(original.GetTrack()) != 4 || len(split.GetTrack()) != 2 {
+		t.Errorf("incorrect tracks lengths after split: %d and %d, expected: %d and %d", len(original.GetTrack()), len(split.GetTrack()), 4, 2)
+	}
+	if original.IsActive() || original.GetLastSeenAt().Frame != 3 || original.GetNoMatchTimes() != 2 {
+		t.Errorf("incorrect state of original track: active %t, last seen %v, no match %d", original.IsActive(), original.GetLastSeenAt(), original.GetNoMatchTimes())
+	}
+	if split.GetCreatedAt().Frame != 4 || split.GetLastSeenAt().Frame != 5 {
+		t.Errorf("incorrect stamps of split track: %v %v, expected frames: %d %d", split.GetCreatedAt(), split.GetLastSeenAt(), 4, 5)
+	}
+	if split.GetClass() != "car" || !split.IsPinned() || original.GetClass() != "car" || !original.IsPinned() {
+		t.Errorf("incorrect class and pin after split: %q %t (original: %q %t), expected: %q %t", split.GetClass(), split.IsPinned(), original.GetClass(), original.IsPinned(), "car", true)
+	}
+	result, err := tracker.MatchObjectsWithResult([]*SimpleBlob{NewSimpleBlob(Rectangle{X: 22.0, Y: 10.0, Width: 20.0, Height: 20.0})})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result.Matched) != 1 || result.Matched[0].TrackID != splitID {
+		t.Errorf("incorrect matches after split: %v, expected track %s", result.Matched, splitID)
+	}
+	if _, err = tracker.SplitTrack(splitID, 100); !errors.Is(err, ErrInvalidSplit) {
+		t.Errorf("incorrect error for split after the last point: %v, expected: %v", err, ErrInvalidSplit)
+	}
+}
+
+func TestMergeSplitFeature(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10))
+	firstID, secondID, err := buildTwoTracks(tracker, 6, 3)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	tracker.Objects[firstID].SetFeature([]float32{1, 0})
+	tracker.Objects[secondID].SetFeature([]float32{0, 1})
+	if err = tracker.MergeTracks(firstID, secondID); err != nil {
+		t.Error(err)
+		return
+	}
+	merged := tracker.Objects[firstID]
+	if feature := merged.GetFeature(); len(feature) != 2 || feature[1] != 1 {
+		t.Errorf("incorrect feature of merged track: %v, expected feature of the newer track: %v", feature, []float32{0, 1})
+	}
+	splitID, err := tracker.SplitTrack(firstID, 3)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if feature := tracker.Objects[splitID].GetFeature(); len(feature) != 2 || feature[1] != 1 {
+		t.Errorf("incorrect feature of split track: %v, expected: %v", feature, []float32{0, 1})
+	}
+}
+
+func TestMergeSplitGallery(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10), WithFeatureBudget(3))
+	firstID, secondID, err := buildTwoTracks(tracker, 6, 3)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	first, second := tracker.Objects[firstID], tracker.Objects[secondID]
+	first.gallery = [][]float32{{1, 0}, {1, 1}}
+	second.gallery = [][]float32{{0, 1}, {0, 2}}
+	if err = tracker.MergeTracks(firstID, secondID); err != nil {
+		t.Error(err)
+		return
+	}
+	gallery := tracker.Objects[firstID].GetGallery()
+	expected := [][]float32{{1, 1}, {0, 1}, {0, 2}}
+	if len(gallery) != len(expected) {
+		t.Errorf("incorrect gallery size after merge: %d, expected: %d", len(gallery), len(expected))
+		return
+	}
+	for i := range expected {
+		if gallery[i][0] != expected[i][0] || gallery[i][1] != expected[i][1] {
+			t.Errorf("incorrect gallery feature %d after merge: %v, expected: %v", i, gallery[i], expected[i])
+		}
+	}
+	splitID, err := tracker.SplitTrack(firstID, 3)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	splitGallery := tracker.Objects[splitID].GetGallery()
+	if len(splitGallery) != len(expected) {
+		t.Errorf("incorrect gallery size of split track: %d, expected: %d", len(splitGallery), len(expected))
+		return
+	}
+	// Galleries should not share backing array
+	splitGallery[0] = nil
+	if tracker.Objects[firstID].GetGallery()[0] == nil {
+		t.Errorf("gallery of split track should be copy of the original one")
+	}
+}
@@ -0,0 +1,107 @@
+// Package metrics provides distance and similarity functions which are shared by appearance matching and gating
+package metrics
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	// ErrDimensionMismatch is returned when vectors have different dimensions
+	ErrDimensionMismatch = errors.New("dimension mismatch")
+	// ErrSingularMatrix is returned when covariance matrix can't be inverted
+	ErrSingularMatrix = errors.New("singular matrix")
+)
+
+// chi2Inv95 holds 0.95 quantiles of the chi-square distribution for 1..9 degrees of freedom
+var chi2Inv95 = [...]float64{
+	3.8415,
+	5.9915,
+	7.8147,
+	9.4877,
+	11.070,
+	12.592,
+	14.067,
+	15.507,
+	16.919,
+}
+
+// Chi2Threshold95 returns 0.95 quantile of the chi-square distribution with given degrees of freedom (1..9).
+// It is the common gating threshold for squared Mahalanobis distance: e.g. 4 degrees of freedom for (x, y, aspect ratio, height) measurements
+func Chi2Threshold95(degreesOfFreedom int) (float64, bool) {
+	if degreesOfFreedom < 1 || degreesOfFreedom > len(chi2Inv95) {
+		return 0, false
+	}
+	return chi2Inv95[degreesOfFreedom-1], true
+}
+
+// Dot returns dot product of two vectors
+func Dot(a, b []float64) (float64, error) {
+	if len(a) != len(b) {
+		return 0, ErrDimensionMismatch
+	}
+	sum := 0.0
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum, nil
+}
+
+// CosineSimilarity returns cosine of angle between two vectors (e.g. appearance embeddings). Zero vectors have zero similarity with any vector
+func CosineSimilarity(a, b []float64) (float64, error) {
+	if len(a) != len(b) {
+		return 0, ErrDimensionMismatch
+	}
+	dot, normA, normB := 0.0, 0.0, 0.0
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0, nil
+	}
+	return dot / math.Sqrt(normA*normB), nil
+}
+
+// CosineDistance returns 1 - cosine similarity of two vectors. Value is in [0; 2] range
+func CosineDistance(a, b []float64) (float64, error) {
+	similarity, err := CosineSimilarity(a, b)
+	if err != nil {
+		return 0, err
+	}
+	return 1 - similarity, nil
+}
+
+// SquaredEuclidean returns squared euclidean distance between two vectors. It is cheaper than euclidean distance and keeps the same order
+func SquaredEuclidean(a, b []float64) (float64, error) {
+	if len(a) != len(b) {
+		return 0, ErrDimensionMismatch
+	}
+	sum := 0.0
+	for i := range a {
+		diff := a[i] - b[i]
+		sum += diff * diff
+	}
+	return sum, nil
+}
+
+// NormalizedCenterDistance returns distance between centers (x1, y1) and (x2, y2) divided by scale
+// (e.g. diagonal of object or of the whole frame), so thresholds do not depend on objects sizes or frame resolution.
+// Returns +Inf for non-positive scale
+func NormalizedCenterDistance(x1, y1, x2, y2, scale float64) float64 {
+	if scale <= 0 {
+		return math.Inf(1)
+	}
+	return math.Hypot(x1-x2, y1-y2) / scale
+}
+
+// SquaredMahalanobis2D returns squared Mahalanobis distance of 2D residual (dx, dy) for covariance matrix [[sxx, sxy], [sxy, syy]].
+// For Kalman filter the covariance is innovation covariance S = H*P*H^T + R
+func SquaredMahalanobis2D(dx, dy, sxx, sxy, syy float64) (float64, error) {
+	det := sxx*syy - sxy*sxy
+	if det <= 0 || math.IsNaN(det) {
+		return 0, ErrSingularMatrix
+	}
+	return (dx*dx*syy - 2*dx*dy*sxy + dy*dy*sxx) / det, nil
+}
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+const (
+	eps = 0.00001
+)
+
+func TestCosine(t *testing.T) {
+	similarity, err := CosineSimilarity([]float64{1, 0}, []float64{1, 1})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if math.Abs(similarity-math.Sqrt2/2) > eps {
+		t.Errorf("incorrect cosine similarity: %v, expected: %v", similarity, math.Sqrt2/2)
+	}
+	distance, err := CosineDistance([]float64{1, 0}, []float64{-1, 0})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if math.Abs(distance-2) > eps {
+		t.Errorf("incorrect cosine distance: %v, expected: %v", distance, 2.0)
+	}
+	_, err = CosineSimilarity([]float64{1, 0}, []float64{1})
+	if !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("incorrect error: %v, expected: %v", err, ErrDimensionMismatch)
+	}
+}
+
+func TestDistances(t *testing.T) {
+	squared, err := SquaredEuclidean([]float64{1, 2, 3}, []float64{4, 6, 3})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if squared != 25 {
+		t.Errorf("incorrect squared euclidean distance: %v, expected: %v", squared, 25.0)
+	}
+	normalized := NormalizedCenterDistance(0, 0, 3, 4, 10)
+	if math.Abs(normalized-0.5) > eps {
+		t.Errorf("incorrect normalized center distance: %v, expected: %v", normalized, 0.5)
+	}
+	threshold, ok := Chi2Threshold95(4)
+	if !ok || threshold != 9.4877 {
+		t.Errorf("incorrect chi-square threshold: %v (found: %v), expected: %v", threshold, ok, 9.4877)
+	}
+	if _, ok := Chi2Threshold95(10); ok {
+		t.Errorf("chi-square threshold should not be available for 10 degrees of freedom")
+	}
+}
+
+func TestSquaredMahalanobis2D(t *testing.T) {
+	// Identity covariance gives squared euclidean distance
+	distance, err := SquaredMahalanobis2D(3, 4, 1, 0, 1)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if math.Abs(distance-25) > eps {
+		t.Errorf("incorrect squared Mahalanobis distance: %v, expected: %v", distance, 25.0)
+	}
+	// Larger variance along X makes residual along X cheaper
+	distance, err = SquaredMahalanobis2D(4, 0, 4, 0, 1)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if math.Abs(distance-4) > eps {
+		t.Errorf("incorrect squared Mahalanobis distance: %v, expected: %v", distance, 4.0)
+	}
+	if _, err = SquaredMahalanobis2D(1, 1, 1, 1, 1); !errors.Is(err, ErrSingularMatrix) {
+		t.Errorf("incorrect error for singular matrix: %v, expected: %v", err, ErrSingularMatrix)
+	}
+}
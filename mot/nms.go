@@ -0,0 +1,167 @@
+package mot
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// NonMaxSuppression returns indices of boxes which survive non-maximum suppression:
+// boxes are visited in descending order of scores and each box which overlaps already kept one with IoU greater than iouThreshold is dropped.
+// Indices are returned in ascending order
+func NonMaxSuppression(boxes []Rectangle, scores []float64, iouThreshold float64) ([]int, error) {
+	return nonMaxSuppression(boxes, scores, nil, iouThreshold)
+}
+
+// NonMaxSuppressionByClass does the same as NonMaxSuppression, but boxes of different classes never suppress each other
+// (e.g. overlapping person and bicycle are both kept)
+func NonMaxSuppressionByClass(boxes []Rectangle, scores []float64, classes []string, iouThreshold float64) ([]int, error) {
+	if len(boxes) != len(classes) {
+		return nil, errors.Wrapf(ErrLengthMismatch, "Boxes number is %d, classes number is %d", len(boxes), len(classes))
+	}
+	return nonMaxSuppression(boxes, scores, classes, iouThreshold)
+}
+
+// nonMaxSuppression implements NonMaxSuppression. Nil classes make it class-agnostic
+func nonMaxSuppression(boxes []Rectangle, scores []float64, classes []string, iouThreshold float64) ([]int, error) {
+	if len(boxes) != len(scores) {
+		return nil, errors.Wrapf(ErrLengthMismatch, "Boxes number is %d, scores number is %d", len(boxes), len(scores))
+	}
+	order := scoreOrder(scores)
+	kept := make([]int, 0, len(boxes))
+	for _, i := range order {
+		suppressed := false
+		for _, j := range kept {
+			if !sameClass(classes, i, j) {
+				continue
+			}
+			if IoU(boxes[i], boxes[j]) > iouThreshold {
+				suppressed = true
+				break
+			}
+		}
+		if !suppressed {
+			kept = append(kept, i)
+		}
+	}
+	sort.Ints(kept)
+	return kept, nil
+}
+
+// MergeBoxes groups boxes which overlap the best-scored box of a group with IoU greater than iouThreshold
+// and replaces each group with single box: coordinates are averaged with scores as weights, the best score is kept.
+// It suits ensemble detectors which produce several slightly shifted boxes for the same object
+func MergeBoxes(boxes []Rectangle, scores []float64, iouThreshold float64) ([]Rectangle, []float64, error) {
+	merged, mergedScores, _, err := mergeBoxes(boxes, scores, nil, iouThreshold)
+	return merged, mergedScores, err
+}
+
+// MergeBoxesByClass does the same as MergeBoxes, but only boxes of the same class are merged. Classes of merged boxes are returned as well
+func MergeBoxesByClass(boxes []Rectangle, scores []float64, classes []string, iouThreshold float64) ([]Rectangle, []float64, []string, error) {
+	if len(boxes) != len(classes) {
+		return nil, nil, nil, errors.Wrapf(ErrLengthMismatch, "Boxes number is %d, classes number is %d", len(boxes), len(classes))
+	}
+	return mergeBoxes(boxes, scores, classes, iouThreshold)
+}
+
+// mergeBoxes implements MergeBoxes. Nil classes make it class-agnostic (and nil classes are returned then)
+func mergeBoxes(boxes []Rectangle, scores []float64, classes []string, iouThreshold float64) ([]Rectangle, []float64, []string, error) {
+	if len(boxes) != len(scores) {
+		return nil, nil, nil, errors.Wrapf(ErrLengthMismatch, "Boxes number is %d, scores number is %d", len(boxes), len(scores))
+	}
+	order := scoreOrder(scores)
+	used := make([]bool, len(boxes))
+	mergedBoxes := make([]Rectangle, 0, len(boxes))
+	mergedScores := make([]float64, 0, len(boxes))
+	var mergedClasses []string
+	if classes != nil {
+		mergedClasses = make([]string, 0, len(boxes))
+	}
+	for _, i := range order {
+		if used[i] {
+			continue
+		}
+		var x, y, width, height, weights float64
+		for _, j := range order {
+			if used[j] || (j != i && (!sameClass(classes, i, j) || IoU(boxes[i], boxes[j]) <= iouThreshold)) {
+				continue
+			}
+			used[j] = true
+			weight := scores[j]
+			if weight <= 0 {
+				// Keep zero-scored boxes from vanishing out of the average
+				weight = minMeasurementWeight
+			}
+			x += boxes[j].X * weight
+			y += boxes[j].Y * weight
+			width += boxes[j].Width * weight
+			height += boxes[j].Height * weight
+			weights += weight
+		}
+		mergedBoxes = append(mergedBoxes, Rectangle{X: x / weights, Y: y / weights, Width: width / weights, Height: height / weights})
+		mergedScores = append(mergedScores, scores[i])
+		if classes != nil {
+			mergedClasses = append(mergedClasses, classes[i])
+		}
+	}
+	return mergedBoxes, mergedScores, mergedClasses, nil
+}
+
+// sameClass checks if boxes with given indices have the same class. Nil classes are treated as single class
+func sameClass(classes []string, i, j int) bool {
+	return classes == nil || classes[i] == classes[j]
+}
+
+// scoreOrder returns indices of scores sorted in descending order (ties are kept in input order)
+func scoreOrder(scores []float64) []int {
+	order := make([]int, len(scores))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[order[i]] > scores[order[j]]
+	})
+	return order
+}
+
+// SetNMSThreshold enables non-maximum suppression of incoming detections: detection which overlaps more confident one of the same class
+// with IoU greater than iouThreshold is ignored (reported in MatchResult.Rejected), so duplicate boxes do not spawn duplicate tracks.
+// Zero disables suppression
+func (tracker *SimpleTracker) SetNMSThreshold(iouThreshold float64) {
+	tracker.filter.nmsThreshold = iouThreshold
+}
+
+// GetNMSThreshold returns IoU threshold for non-maximum suppression of incoming detections
+func (tracker *SimpleTracker) GetNMSThreshold() float64 {
+	return tracker.filter.nmsThreshold
+}
+
+// suppressDetections marks detections which are suppressed by more confident ones.
+// Detections which have been rejected already are not taken into account, detections of different classes do not suppress each other
+func (tracker *SimpleTracker) suppressDetections(newObjects []*SimpleBlob, result *MatchResult) {
+	rejected := tracker.buffers.rejected
+	kept := make([]int, 0, len(newObjects))
+	for i := range newObjects {
+		if !rejected[i] {
+			kept = append(kept, i)
+		}
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		return newObjects[kept[i]].confidence > newObjects[kept[j]].confidence
+	})
+	for k, i := range kept {
+		for _, j := range kept[:k] {
+			if rejected[j] || newObjects[i].class != newObjects[j].class || IoU(newObjects[i].currentBBox, newObjects[j].currentBBox) <= tracker.filter.nmsThreshold {
+				continue
+			}
+			rejected[i] = true
+			if result != nil {
+				result.Rejected = append(result.Rejected, i)
+			}
+			break
+		}
+	}
+	if result != nil {
+		sort.Ints(result.Rejected)
+	}
+}
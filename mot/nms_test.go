@@ -0,0 +1,111 @@
+package mot
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNonMaxSuppression(t *testing.T) {
+	boxes := []Rectangle{
+		{X: 0.0, Y: 0.0, Width: 10.0, Height: 10.0},
+		{X: 1.0, Y: 1.0, Width: 10.0, Height: 10.0},
+		{X: 50.0, Y: 50.0, Width: 10.0, Height: 10.0},
+	}
+	scores := []float64{0.6, 0.9, 0.5}
+	kept, err := NonMaxSuppression(boxes, scores, 0.5)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(kept) != 2 || kept[0] != 1 || kept[1] != 2 {
+		t.Errorf("incorrect kept boxes: %v, expected: %v", kept, []int{1, 2})
+	}
+	merged, mergedScores, err := MergeBoxes(boxes, scores, 0.5)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(merged) != 2 || mergedScores[0] != 0.9 {
+		t.Errorf("incorrect merged boxes: %v (scores %v), expected 2 boxes with the best score %v first", merged, mergedScores, 0.9)
+		return
+	}
+	expectedX := (0.0*0.6 + 1.0*0.9) / 1.5
+	if math.Abs(merged[0].X-expectedX) > eps {
+		t.Errorf("incorrect merged X: %v, expected: %v", merged[0].X, expectedX)
+	}
+	_, err = NonMaxSuppression(boxes, scores[:1], 0.5)
+	if !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("incorrect error: %v, expected: %v", err, ErrLengthMismatch)
+	}
+}
+
+func TestTrackerNMS(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10), WithNMSThreshold(0.5))
+	weak := NewSimpleBlob(Rectangle{X: 0.0, Y: 0.0, Width: 20.0, Height: 20.0})
+	weak.SetConfidence(0.4)
+	strong := NewSimpleBlob(Rectangle{X: 1.0, Y: 1.0, Width: 20.0, Height: 20.0})
+	strong.SetConfidence(0.8)
+	result, err := tracker.MatchObjectsWithResult([]*SimpleBlob{weak, strong})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result.Rejected) != 1 || result.Rejected[0] != 0 {
+		t.Errorf("incorrect rejected detections: %v, expected: %v", result.Rejected, []int{0})
+	}
+	if len(tracker.Objects) != 1 {
+		t.Errorf("incorrect number of tracks: %d, expected: %d", len(tracker.Objects), 1)
+	}
+}
+
+func TestNonMaxSuppressionByClass(t *testing.T) {
+	boxes := []Rectangle{
+		{X: 0.0, Y: 0.0, Width: 10.0, Height: 10.0},
+		{X: 1.0, Y: 1.0, Width: 10.0, Height: 10.0},
+		{X: 0.5, Y: 0.5, Width: 10.0, Height: 10.0},
+	}
+	scores := []float64{0.6, 0.9, 0.5}
+	classes := []string{"person", "bicycle", "person"}
+	kept, err := NonMaxSuppressionByClass(boxes, scores, classes, 0.5)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(kept) != 2 || kept[0] != 0 || kept[1] != 1 {
+		t.Errorf("incorrect kept boxes: %v, expected: %v", kept, []int{0, 1})
+	}
+	merged, _, mergedClasses, err := MergeBoxesByClass(boxes, scores, classes, 0.5)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(merged) != 2 || mergedClasses[0] != "bicycle" || mergedClasses[1] != "person" {
+		t.Errorf("incorrect merged classes: %v, expected: %v", mergedClasses, []string{"bicycle", "person"})
+	}
+	_, err = NonMaxSuppressionByClass(boxes, scores, classes[:1], 0.5)
+	if !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("incorrect error: %v, expected: %v", err, ErrLengthMismatch)
+	}
+}
+
+func TestTrackerNMSByClass(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10), WithNMSThreshold(0.5))
+	person := NewSimpleBlob(Rectangle{X: 0.0, Y: 0.0, Width: 20.0, Height: 20.0})
+	person.SetConfidence(0.4)
+	person.SetClass("person")
+	bicycle := NewSimpleBlob(Rectangle{X: 1.0, Y: 1.0, Width: 20.0, Height: 20.0})
+	bicycle.SetConfidence(0.8)
+	bicycle.SetClass("bicycle")
+	result, err := tracker.MatchObjectsWithResult([]*SimpleBlob{person, bicycle})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result.Rejected) != 0 {
+		t.Errorf("incorrect rejected detections: %v, expected none", result.Rejected)
+	}
+	if len(result.Created) != 2 {
+		t.Errorf("incorrect number of created tracks: %d, expected: %d", len(result.Created), 2)
+	}
+}
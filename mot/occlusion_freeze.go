@@ -0,0 +1,50 @@
+package mot
+
+// SetOcclusionFreeze enables occlusion-freeze mode: when matched detection's area drops below minAreaRatio of track's area
+// (e.g. 0.5) while another track overlaps the track, size of the track is kept and only its position is updated,
+// so partially hidden object does not collapse onto the occluder. Zero disables the mode
+func (tracker *SimpleTracker) SetOcclusionFreeze(minAreaRatio float64) {
+	tracker.occlusionFreezeRatio = minAreaRatio
+}
+
+// GetOcclusionFreeze returns min area ratio which triggers occlusion-freeze mode (zero if it is disabled)
+func (tracker *SimpleTracker) GetOcclusionFreeze() float64 {
+	return tracker.occlusionFreezeRatio
+}
+
+// freezeSize returns detection which should be used to update the track: either the given one
+// or its copy with track's size if the track is considered to be occluded
+func (tracker *SimpleTracker) freezeSize(object *SimpleBlob, newObject *SimpleBlob) *SimpleBlob {
+	if tracker.occlusionFreezeRatio <= 0 {
+		return newObject
+	}
+	area := object.currentBBox.Area()
+	if area <= 0 || newObject.currentBBox.Area() >= area*tracker.occlusionFreezeRatio {
+		return newObject
+	}
+	if !tracker.isOverlapped(object) {
+		return newObject
+	}
+	frozen := *newObject
+	frozen.currentBBox = Rectangle{
+		X:      newObject.currentCenter.X - object.currentBBox.Width/2,
+		Y:      newObject.currentCenter.Y - object.currentBBox.Height/2,
+		Width:  object.currentBBox.Width,
+		Height: object.currentBBox.Height,
+	}
+	frozen.diagonal = object.diagonal
+	return &frozen
+}
+
+// isOverlapped checks whether any other track overlaps the given one
+func (tracker *SimpleTracker) isOverlapped(object *SimpleBlob) bool {
+	for _, other := range tracker.storage.tracks {
+		if other == object {
+			continue
+		}
+		if object.currentBBox.Intersect(other.currentBBox).Area() > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package mot
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOcclusionFreeze(t *testing.T) {
+	for _, freeze := range []bool{true, false} {
+		tracker := NewSimpleTracker(WithMinDistThreshold(30.0), WithMaxNoMatch(10))
+		if freeze {
+			tracker.SetOcclusionFreeze(0.5)
+		}
+		result, err := tracker.MatchObjectsWithResult([]*SimpleBlob{
+			NewSimpleBlob(Rectangle{X: 100.0, Y: 100.0, Width: 40.0, Height: 80.0}),
+			NewSimpleBlob(Rectangle{X: 130.0, Y: 100.0, Width: 40.0, Height: 80.0}),
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		occludedID := result.Created[0].TrackID
+		// The first object is half-hidden by the second one
+		err = tracker.MatchObjects([]*SimpleBlob{
+			NewSimpleBlob(Rectangle{X: 100.0, Y: 100.0, Width: 16.0, Height: 80.0}),
+			NewSimpleBlob(Rectangle{X: 130.0, Y: 100.0, Width: 40.0, Height: 80.0}),
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		occluded := tracker.Objects[occludedID]
+		if occluded == nil {
+			t.Errorf("occluded track %s should be kept", occludedID)
+			return
+		}
+		width := occluded.GetBBox().Width
+		if freeze && math.Abs(width-40.0) > 1.0 {
+			t.Errorf("incorrect width of frozen track: %f, expected: %f", width, 40.0)
+		}
+		if !freeze && math.Abs(width-40.0) < 1.0 {
+			t.Errorf("incorrect width of track without freeze: %f, expected about: %f", width, 16.0)
+		}
+	}
+}
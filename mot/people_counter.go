@@ -0,0 +1,184 @@
+package mot
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Entrance is place where people enter and leave the venue. It is either counting line or polygon:
+//   - line: crossing it in InDirection is entry, crossing in opposite direction is exit
+//   - polygon (takes precedence if it has points): it is inner area of entrance, so moving into it is entry and moving out of it is exit
+type Entrance struct {
+	Name        string
+	Line        Segment
+	InDirection CrossingDirection
+	Zone        Polygon
+}
+
+// inside checks if point is on the inner side of entrance. Points lying exactly on the line are not classified
+func (entrance *Entrance) inside(pt Point) (bool, bool) {
+	if len(entrance.Zone.Points) > 0 {
+		return entrance.Zone.Contains(pt), true
+	}
+	side := entrance.Line.Side(pt)
+	if side == 0 {
+		return false, false
+	}
+	if entrance.InDirection == CrossingForward {
+		return side > 0, true
+	}
+	return side < 0, true
+}
+
+// EntranceCount is number of entries and exits through some entrance
+type EntranceCount struct {
+	Entrance string
+	Entries  int
+	Exits    int
+}
+
+// entranceState is state of the track relatively to some entrance
+type entranceState struct {
+	// Whether track has been observed already
+	known bool
+	// Committed side of entrance
+	inside bool
+	// Number of consecutive observations on the opposite side
+	hits int
+}
+
+// peopleTrack is state of the track which is tracked by PeopleCounter
+type peopleTrack struct {
+	position  Point
+	entrances []entranceState
+}
+
+// PeopleCounter maintains current occupancy of the venue (entries minus exits) from tracker events:
+// subscribe it via tracker.OnEvent(counter.Handle) (or EventStream).
+// Transition of track through entrance is committed only after track has been observed on the new side for debounce consecutive events,
+// so people standing in the doorway do not produce flapping entries and exits. Initial side of a track is not counted.
+// It is safe for concurrent use (e.g. with TiledTracker)
+type PeopleCounter struct {
+	mu        sync.Mutex
+	entrances []Entrance
+	debounce  int
+	tracks    map[uuid.UUID]*peopleTrack
+	counts    []EntranceCount
+	occupancy int
+}
+
+// NewPeopleCounter creates counter for given entrances. Debounce is number of consecutive events (at least 1) which are needed to commit transition.
+// Returns ErrInvalidGeometry if some entrance has no name, duplicate name or neither valid polygon nor line
+func NewPeopleCounter(entrances []Entrance, debounce int) (*PeopleCounter, error) {
+	names := make(map[string]struct{}, len(entrances))
+	counts := make([]EntranceCount, len(entrances))
+	for i, entrance := range entrances {
+		if entrance.Name == "" {
+			return nil, errors.Wrapf(ErrInvalidGeometry, "Entrance at index %d has no name", i)
+		}
+		if _, ok := names[entrance.Name]; ok {
+			return nil, errors.Wrapf(ErrInvalidGeometry, "Duplicate entrance name '%s'", entrance.Name)
+		}
+		names[entrance.Name] = struct{}{}
+		if len(entrance.Zone.Points) > 0 && len(entrance.Zone.Points) < 3 {
+			return nil, errors.Wrapf(ErrInvalidGeometry, "Zone of entrance '%s' needs at least 3 points, got %d", entrance.Name, len(entrance.Zone.Points))
+		}
+		if len(entrance.Zone.Points) == 0 && entrance.Line.Length() == 0 {
+			return nil, errors.Wrapf(ErrInvalidGeometry, "Line of entrance '%s' has zero length", entrance.Name)
+		}
+		counts[i].Entrance = entrance.Name
+	}
+	if debounce < 1 {
+		debounce = 1
+	}
+	return &PeopleCounter{
+		entrances: append([]Entrance{}, entrances...),
+		debounce:  debounce,
+		tracks:    make(map[uuid.UUID]*peopleTrack),
+		counts:    counts,
+	}, nil
+}
+
+// Handle processes tracker event
+func (counter *PeopleCounter) Handle(event Event) {
+	counter.mu.Lock()
+	defer counter.mu.Unlock()
+	if event.Type == EventTrackRemoved {
+		delete(counter.tracks, event.TrackID)
+		return
+	}
+	center := event.BBox.Center()
+	track, ok := counter.tracks[event.TrackID]
+	if !ok {
+		track = &peopleTrack{position: center, entrances: make([]entranceState, len(counter.entrances))}
+		counter.tracks[event.TrackID] = track
+	}
+	previous := track.position
+	track.position = center
+	for i := range counter.entrances {
+		entrance := &counter.entrances[i]
+		state := &track.entrances[i]
+		inside, ok := entrance.inside(center)
+		if !ok {
+			continue
+		}
+		if !state.known {
+			state.known = true
+			state.inside = inside
+			continue
+		}
+		if inside == state.inside {
+			state.hits = 0
+			continue
+		}
+		// Line splits the whole plane, so passing by its ends changes side without crossing it
+		if state.hits == 0 && len(entrance.Zone.Points) == 0 {
+			if _, crossed := entrance.Line.Crossing(previous, center); !crossed {
+				state.inside = inside
+				continue
+			}
+		}
+		state.hits++
+		if state.hits < counter.debounce {
+			continue
+		}
+		state.hits = 0
+		state.inside = inside
+		if inside {
+			counter.counts[i].Entries++
+			counter.occupancy++
+		} else {
+			counter.counts[i].Exits++
+			if counter.occupancy > 0 {
+				counter.occupancy--
+			}
+		}
+	}
+}
+
+// Occupancy returns current number of people inside. It never goes below zero (people who have been inside before counter
+// started could leave)
+func (counter *PeopleCounter) Occupancy() int {
+	counter.mu.Lock()
+	defer counter.mu.Unlock()
+	return counter.occupancy
+}
+
+// SetOccupancy overrides current number of people inside (e.g. resets it to zero at closing time)
+func (counter *PeopleCounter) SetOccupancy(occupancy int) {
+	counter.mu.Lock()
+	defer counter.mu.Unlock()
+	if occupancy < 0 {
+		occupancy = 0
+	}
+	counter.occupancy = occupancy
+}
+
+// Counts returns numbers of entries and exits per entrance (in order of entrances)
+func (counter *PeopleCounter) Counts() []EntranceCount {
+	counter.mu.Lock()
+	defer counter.mu.Unlock()
+	return append([]EntranceCount{}, counter.counts...)
+}
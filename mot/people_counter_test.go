@@ -0,0 +1,79 @@
+package mot
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestPeopleCounterValidation(t *testing.T) {
+	_, err := NewPeopleCounter([]Entrance{{Name: "door"}}, 1)
+	if !errors.Is(err, ErrInvalidGeometry) {
+		t.Errorf("incorrect error: %v, expected: %v", err, ErrInvalidGeometry)
+	}
+}
+
+func TestPeopleCounter(t *testing.T) {
+	// Venue is below y = 100 (line goes from right to left, so moving down is forward crossing)
+	door := Entrance{Name: "door", Line: NewSegment(Point{X: 100.0, Y: 100.0}, Point{X: 0.0, Y: 100.0}), InDirection: CrossingBackward}
+	counter, err := NewPeopleCounter([]Entrance{door}, 2)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	person := uuid.New()
+	at := func(y float64) Event {
+		return Event{Type: EventTrackUpdated, TrackID: person, BBox: Rectangle{X: 40.0, Y: y - 10.0, Width: 20.0, Height: 20.0}}
+	}
+	// Enters and stays inside
+	for _, y := range []float64{80.0, 90.0, 110.0, 120.0, 130.0} {
+		counter.Handle(at(y))
+	}
+	if counter.Occupancy() != 1 {
+		t.Errorf("incorrect occupancy after entry: %v, expected: %v", counter.Occupancy(), 1)
+	}
+	// Steps back over the line for single frame only: debounced
+	for _, y := range []float64{95.0, 105.0, 110.0} {
+		counter.Handle(at(y))
+	}
+	counts := counter.Counts()
+	if counter.Occupancy() != 1 || counts[0].Entries != 1 || counts[0].Exits != 0 {
+		t.Errorf("incorrect state after flapping: occupancy %v, counts %v", counter.Occupancy(), counts)
+	}
+	// Leaves
+	for _, y := range []float64{90.0, 80.0, 70.0} {
+		counter.Handle(at(y))
+	}
+	if counter.Occupancy() != 0 {
+		t.Errorf("incorrect occupancy after exit: %v, expected: %v", counter.Occupancy(), 0)
+	}
+	// Walks around the line end: no crossing
+	counter.Handle(Event{Type: EventTrackUpdated, TrackID: person, BBox: Rectangle{X: 190.0, Y: 60.0, Width: 20.0, Height: 20.0}})
+	counter.Handle(Event{Type: EventTrackUpdated, TrackID: person, BBox: Rectangle{X: 190.0, Y: 120.0, Width: 20.0, Height: 20.0}})
+	counter.Handle(Event{Type: EventTrackUpdated, TrackID: person, BBox: Rectangle{X: 190.0, Y: 130.0, Width: 20.0, Height: 20.0}})
+	if counter.Occupancy() != 0 {
+		t.Errorf("incorrect occupancy after passing by: %v, expected: %v", counter.Occupancy(), 0)
+	}
+}
+
+func TestPeopleCounterZone(t *testing.T) {
+	zone, _ := NewPolygon([]Point{{X: 0.0, Y: 100.0}, {X: 100.0, Y: 100.0}, {X: 100.0, Y: 200.0}, {X: 0.0, Y: 200.0}})
+	counter, err := NewPeopleCounter([]Entrance{{Name: "hall", Zone: zone}}, 1)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	tracker := NewSimpleTracker(WithMinDistThreshold(30.0))
+	tracker.OnEvent(counter.Handle)
+	for i := 0; i < 8; i++ {
+		err := tracker.MatchObjects([]*SimpleBlob{NewSimpleBlob(Rectangle{X: 40.0, Y: 40.0 + 15.0*float64(i), Width: 20.0, Height: 20.0})})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if counter.Occupancy() != 1 {
+		t.Errorf("incorrect occupancy: %v, expected: %v", counter.Occupancy(), 1)
+	}
+}
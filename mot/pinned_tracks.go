@@ -0,0 +1,48 @@
+package mot
+
+import (
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// PinTrack marks track as pinned: it is never removed by max no match cleanup or eviction (see SetMaxObjects), so it keeps being predicted forward
+// through long occlusions until it is matched again or unpinned. Pin does not protect track which has not been confirmed yet
+// (see SetMinConsecutiveMatches): it is still removed as noise once it is lost before confirmation
+func (tracker *SimpleTracker) PinTrack(id uuid.UUID) error {
+	blob, ok := tracker.storage.get(id)
+	if !ok {
+		return errors.Wrapf(ErrUnknownTrack, "Can't pin track %s", id.String())
+	}
+	blob.pinned = true
+	return nil
+}
+
+// UnpinTrack removes pin from track. Track will be removed on the next frame if it has not been found for too long
+func (tracker *SimpleTracker) UnpinTrack(id uuid.UUID) error {
+	blob, ok := tracker.storage.get(id)
+	if !ok {
+		return errors.Wrapf(ErrUnknownTrack, "Can't unpin track %s", id.String())
+	}
+	blob.pinned = false
+	return nil
+}
+
+// PinTrack marks track of any tile as pinned. See SimpleTracker.PinTrack
+func (tracker *TiledTracker) PinTrack(id uuid.UUID) error {
+	blob, ok := tracker.GetTrack(id)
+	if !ok {
+		return errors.Wrapf(ErrUnknownTrack, "Can't pin track %s", id.String())
+	}
+	blob.pinned = true
+	return nil
+}
+
+// UnpinTrack removes pin from track of any tile
+func (tracker *TiledTracker) UnpinTrack(id uuid.UUID) error {
+	blob, ok := tracker.GetTrack(id)
+	if !ok {
+		return errors.Wrapf(ErrUnknownTrack, "Can't unpin track %s", id.String())
+	}
+	blob.pinned = false
+	return nil
+}
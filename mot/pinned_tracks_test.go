@@ -0,0 +1,55 @@
+package mot
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestPinnedTrack(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(2))
+	result, err := tracker.MatchObjectsWithResult([]*SimpleBlob{
+		NewSimpleBlob(Rectangle{X: 10.0, Y: 10.0, Width: 20.0, Height: 20.0}),
+		NewSimpleBlob(Rectangle{X: 200.0, Y: 10.0, Width: 20.0, Height: 20.0}),
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	pinnedID := result.Created[0].TrackID
+	err = tracker.PinTrack(pinnedID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for i := 0; i < 10; i++ {
+		err = tracker.MatchObjects([]*SimpleBlob{})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if len(tracker.Objects) != 1 {
+		t.Errorf("incorrect number of tracks: %d, expected: %d", len(tracker.Objects), 1)
+	}
+	if _, ok := tracker.Objects[pinnedID]; !ok {
+		t.Errorf("pinned track %s has been removed", pinnedID)
+	}
+	err = tracker.UnpinTrack(pinnedID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = tracker.MatchObjects([]*SimpleBlob{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(tracker.Objects) != 0 {
+		t.Errorf("incorrect number of tracks after unpinning: %d, expected: %d", len(tracker.Objects), 0)
+	}
+	if err = tracker.PinTrack(uuid.New()); !errors.Is(err, ErrUnknownTrack) {
+		t.Errorf("incorrect error for unknown track: %v, expected: %v", err, ErrUnknownTrack)
+	}
+}
@@ -0,0 +1,75 @@
+package mot
+
+import (
+	"context"
+)
+
+// FrameMatcher is the interface which trackers implement to be used as pipeline stage
+type FrameMatcher interface {
+	MatchObjectsWithResultCtx(ctx context.Context, newObjects []*SimpleBlob) (*MatchResult, error)
+}
+
+// PipelineOutput is the outcome of processing single frame in Pipeline
+type PipelineOutput struct {
+	// Sequence number of the frame (starting from zero)
+	Frame int
+	// Association report. It is nil if Err is not nil
+	Result *MatchResult
+	// Error which occurred during matching
+	Err error
+}
+
+// Pipeline turns tracker into stage of channel-based processing pipeline:
+// frames detections are read from the input channel and matching results are written to the output channel
+type Pipeline struct {
+	tracker FrameMatcher
+	input   chan []*SimpleBlob
+	output  chan PipelineOutput
+}
+
+// NewPipeline creates new pipeline for the given tracker. Both input and output channels have bufferSize capacity
+func NewPipeline(tracker FrameMatcher, bufferSize int) *Pipeline {
+	if bufferSize < 0 {
+		bufferSize = 0
+	}
+	return &Pipeline{
+		tracker: tracker,
+		input:   make(chan []*SimpleBlob, bufferSize),
+		output:  make(chan PipelineOutput, bufferSize),
+	}
+}
+
+// Input returns channel for frames detections. Close it to shut the pipeline down gracefully:
+// frames which are already buffered will be processed before the output channel is closed
+func (pipeline *Pipeline) Input() chan<- []*SimpleBlob {
+	return pipeline.input
+}
+
+// Output returns channel with matching results. It is closed when Run returns
+func (pipeline *Pipeline) Output() <-chan PipelineOutput {
+	return pipeline.output
+}
+
+// Run processes frames until the input channel is closed or context is done. It is supposed to be run as goroutine.
+// Returns context's error if processing has been interrupted by context
+func (pipeline *Pipeline) Run(ctx context.Context) error {
+	defer close(pipeline.output)
+	frame := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case newObjects, ok := <-pipeline.input:
+			if !ok {
+				return nil
+			}
+			result, err := pipeline.tracker.MatchObjectsWithResultCtx(ctx, newObjects)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case pipeline.output <- PipelineOutput{Frame: frame, Result: result, Err: err}:
+			}
+			frame++
+		}
+	}
+}
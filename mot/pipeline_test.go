@@ -0,0 +1,58 @@
+package mot
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPipeline(t *testing.T) {
+	tracker := NewNewSimpleTracker(15.0, 5)
+	pipeline := NewPipeline(tracker, 4)
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- pipeline.Run(context.Background())
+	}()
+
+	dt := 1.0 / 25.0
+	framesNum := 10
+	go func() {
+		for i := 0; i < framesNum; i++ {
+			pipeline.Input() <- []*SimpleBlob{NewSimpleBlobWithTime(NewRect(10.0+float64(i), 10.0, 20.0, 20.0), dt)}
+		}
+		close(pipeline.Input())
+	}()
+
+	processed := 0
+	for output := range pipeline.Output() {
+		if output.Err != nil {
+			t.Error(output.Err)
+			return
+		}
+		if output.Frame != processed {
+			t.Errorf("incorrect frame number: %d, expected: %d", output.Frame, processed)
+		}
+		if output.Frame > 0 && len(output.Result.Matched) != 1 {
+			t.Errorf("incorrect number of matched tracks on frame %d: %d, expected: %d", output.Frame, len(output.Result.Matched), 1)
+		}
+		processed++
+	}
+	if processed != framesNum {
+		t.Errorf("incorrect number of processed frames: %d, expected: %d", processed, framesNum)
+	}
+	if err := <-runErr; err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPipelineCancel(t *testing.T) {
+	pipeline := NewPipeline(NewNewSimpleTracker(15.0, 5), 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := pipeline.Run(ctx)
+	if err != context.Canceled {
+		t.Errorf("incorrect error: %v, expected: %v", err, context.Canceled)
+	}
+	if _, ok := <-pipeline.Output(); ok {
+		t.Errorf("output channel should be closed")
+	}
+}
@@ -0,0 +1,81 @@
+package mot
+
+// pointArena hands out fixed-capacity blocks for tracks history. Blocks are carved from large chunks,
+// so history of many tracks is kept in a few big allocations instead of a separate growing slice per track
+type pointArena struct {
+	// Capacity of single block (max track length)
+	blockSize int
+	// Number of blocks in single chunk
+	chunkBlocks int
+	// Blocks which could be reused
+	free [][]Point
+}
+
+func newPointArena(blockSize, chunkBlocks int) *pointArena {
+	return &pointArena{
+		blockSize:   blockSize,
+		chunkBlocks: chunkBlocks,
+		free:        make([][]Point, 0, chunkBlocks),
+	}
+}
+
+// acquire returns empty block with capacity equal to blockSize
+func (arena *pointArena) acquire() []Point {
+	if len(arena.free) == 0 {
+		chunk := make([]Point, arena.blockSize*arena.chunkBlocks)
+		for i := arena.chunkBlocks - 1; i >= 0; i-- {
+			// Full slice expression prevents appends from overflowing into the neighbouring block
+			arena.free = append(arena.free, chunk[i*arena.blockSize:i*arena.blockSize:(i+1)*arena.blockSize])
+		}
+	}
+	block := arena.free[len(arena.free)-1]
+	arena.free[len(arena.free)-1] = nil
+	arena.free = arena.free[:len(arena.free)-1]
+	return block
+}
+
+// release returns block to the arena. Block must not be used after release
+func (arena *pointArena) release(block []Point) {
+	if cap(block) != arena.blockSize {
+		return
+	}
+	arena.free = append(arena.free, block[:0])
+}
+
+// SetTrackArena makes tracker keep tracks history in arena consisting of chunks with chunkTracks tracks each.
+// Only blobs with the same max track length as the one passed here use the arena.
+// Zero or negative chunkTracks disables the arena.
+//
+// When track is removed its history is copied out of the arena, so removed blobs stay valid
+func (tracker *SimpleTracker) SetTrackArena(chunkTracks int, maxTrackLen int) {
+	if chunkTracks <= 0 || maxTrackLen <= 0 {
+		tracker.arena = nil
+		return
+	}
+	tracker.arena = newPointArena(maxTrackLen, chunkTracks)
+}
+
+// attachToArena moves blob's track into arena block
+func (tracker *SimpleTracker) attachToArena(blob *SimpleBlob) {
+	if tracker.arena == nil || blob.arenaBlock != nil || blob.maxTrackLen != tracker.arena.blockSize || len(blob.track) > blob.maxTrackLen {
+		return
+	}
+	block := tracker.arena.acquire()
+	block = append(block, blob.track...)
+	blob.track = block
+	blob.arenaBlock = block
+}
+
+// detachFromArena copies blob's track out of arena and returns the block for reuse
+func (tracker *SimpleTracker) detachFromArena(blob *SimpleBlob) {
+	if blob.arenaBlock == nil {
+		return
+	}
+	track := make([]Point, len(blob.track), blob.maxTrackLen)
+	copy(track, blob.track)
+	blob.track = track
+	if tracker.arena != nil {
+		tracker.arena.release(blob.arenaBlock)
+	}
+	blob.arenaBlock = nil
+}
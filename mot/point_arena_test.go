@@ -0,0 +1,72 @@
+package mot
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPointArenaBlocks(t *testing.T) {
+	arena := newPointArena(3, 2)
+	first := arena.acquire()
+	second := arena.acquire()
+	first = append(first, Point{X: 1}, Point{X: 2}, Point{X: 3})
+	second = append(second, Point{X: 4})
+	if cap(first) != 3 || cap(second) != 3 {
+		t.Errorf("incorrect blocks capacity: %d and %d, expected: %d", cap(first), cap(second), 3)
+	}
+	// Appending to the full block must not overwrite the neighbouring one
+	_ = append(first, Point{X: 100})
+	if second[0].X != 4 {
+		t.Errorf("neighbouring block has been overwritten: %v", second[0])
+	}
+	arena.release(first)
+	third := arena.acquire()
+	if len(third) != 0 || &third[:1][0] != &first[0] {
+		t.Errorf("released block should be reused")
+	}
+}
+
+func TestSimpleTrackerArena(t *testing.T) {
+	maxTrackLen := 5
+	tracker := NewNewSimpleTracker(15.0, 2)
+	tracker.SetTrackArena(4, maxTrackLen)
+	dt := 1.0 / 25.0
+	var trackID [16]byte
+	for frame := 0; frame < 12; frame++ {
+		blob := NewSimpleBlobWithTime(NewRect(10.0+float64(frame), 10.0, 20.0, 20.0), dt)
+		blob.SetMaxTrackLen(maxTrackLen)
+		err := tracker.MatchObjects([]*SimpleBlob{blob})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		trackID = blob.GetID()
+	}
+	blob := tracker.Objects[trackID]
+	if blob.arenaBlock == nil {
+		t.Errorf("track should be kept in arena")
+		return
+	}
+	track := blob.GetTrack()
+	if len(track) != maxTrackLen {
+		t.Errorf("incorrect track length: %d, expected: %d", len(track), maxTrackLen)
+		return
+	}
+	center := blob.GetCenter()
+	last := track[len(track)-1]
+	if math.Abs(last.X-center.X) > eps || math.Abs(last.Y-center.Y) > eps {
+		t.Errorf("incorrect last point of the track: %v, expected: %v", last, center)
+		return
+	}
+	// Make object disappear, so it is removed from the tracker
+	for frame := 0; frame < 3; frame++ {
+		err := tracker.MatchObjects([]*SimpleBlob{})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if blob.arenaBlock != nil || len(blob.GetTrack()) != maxTrackLen {
+		t.Errorf("removed track should be copied out of arena")
+	}
+}
@@ -0,0 +1,41 @@
+package mot
+
+import "github.com/google/uuid"
+
+// TrackPrediction is the position where tracker expects to find the track on the current frame
+type TrackPrediction struct {
+	// Identifier of track
+	TrackID uuid.UUID
+	// Track's bounding box moved to the center predicted by Kalman filter
+	BBox Rectangle
+	// Track's confidence. It decays while track is coasting, so renderers could fade out stale tracks (see SimpleTracker.SetConfidenceDecay)
+	Confidence float64
+}
+
+// GetPredictions returns predicted bounding boxes of all confirmed tracks (ordered by registration time).
+// Predictions are made at the beginning of each MatchObjects call, so they are available even for tracks
+// which have not been matched on the last frame. Boxes are clamped to the frame if SetClampToFrame is used
+// and mapped back to image coordinates if SetLensDistortion is used
+func (tracker *SimpleTracker) GetPredictions() []TrackPrediction {
+	predictions := make([]TrackPrediction, 0, len(tracker.Objects))
+	for _, object := range tracker.storage.tracks {
+		if !object.confirmed {
+			continue
+		}
+		bbox := object.GetPredictedBBox()
+		if tracker.filter.clampEnabled() {
+			bbox = Clamp(bbox, tracker.filter.frameWidth, tracker.filter.frameHeight)
+		}
+		predictions = append(predictions, TrackPrediction{TrackID: object.id, BBox: tracker.distortBBox(bbox), Confidence: object.confidence})
+	}
+	return predictions
+}
+
+// GetPredictions returns predicted bounding boxes of confirmed tracks of all tiles
+func (tracker *TiledTracker) GetPredictions() []TrackPrediction {
+	predictions := make([]TrackPrediction, 0)
+	for _, tile := range tracker.tiles {
+		predictions = append(predictions, tile.GetPredictions()...)
+	}
+	return predictions
+}
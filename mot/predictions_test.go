@@ -0,0 +1,72 @@
+package mot
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGetPredictions(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10))
+	dt := 1.0
+	var blob *SimpleBlob
+	for frame := 0; frame < 5; frame++ {
+		newBlob := NewSimpleBlobWithTime(Rectangle{X: 100.0 + 5.0*float64(frame), Y: 100.0, Width: 10.0, Height: 10.0}, dt)
+		if frame == 0 {
+			blob = newBlob
+		}
+		err := tracker.MatchObjects([]*SimpleBlob{newBlob})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	// Detection is missing, but prediction should keep moving forward
+	err := tracker.MatchObjects([]*SimpleBlob{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	predictions := tracker.GetPredictions()
+	if len(predictions) != 1 || predictions[0].TrackID != blob.GetID() {
+		t.Errorf("incorrect predictions: %v, expected single prediction for track %s", predictions, blob.GetID())
+		return
+	}
+	bbox := predictions[0].BBox
+	if bbox.X <= blob.GetBBox().X {
+		t.Errorf("predicted box should be ahead of the last known one: %v, expected greater than: %v", bbox.X, blob.GetBBox().X)
+	}
+	if math.Abs(bbox.Width-blob.GetBBox().Width) > eps || math.Abs(bbox.Height-blob.GetBBox().Height) > eps {
+		t.Errorf("incorrect predicted box size: %vx%v, expected: %vx%v", bbox.Width, bbox.Height, blob.GetBBox().Width, blob.GetBBox().Height)
+	}
+}
+
+func TestPredictionsConfidence(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10), WithConfidenceDecay(0.5))
+	lostConfidence := 0.0
+	tracker.OnEvent(func(event Event) {
+		if event.Type == EventTrackLost {
+			lostConfidence = event.Confidence
+		}
+	})
+	detection := NewSimpleBlob(Rectangle{X: 100.0, Y: 100.0, Width: 10.0, Height: 10.0})
+	detection.SetConfidence(0.8)
+	err := tracker.MatchObjects([]*SimpleBlob{detection})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for i := 0; i < 2; i++ {
+		err = tracker.MatchObjects([]*SimpleBlob{})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	predictions := tracker.GetPredictions()
+	if len(predictions) != 1 || math.Abs(predictions[0].Confidence-0.2) > eps {
+		t.Errorf("incorrect predictions confidence: %v, expected: %v", predictions, 0.2)
+	}
+	if math.Abs(lostConfidence-0.4) > eps {
+		t.Errorf("incorrect confidence of lost event: %v, expected: %v", lostConfidence, 0.4)
+	}
+}
@@ -0,0 +1,76 @@
+package mot
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// reidRecovery holds settings of appearance-based recovery of removed tracks
+type reidRecovery struct {
+	bank              *FeatureBank
+	maxCosineDistance float64
+	maxAge            time.Duration
+}
+
+// SetReIDRecovery enables appearance-based recovery of removed tracks: appearance of each removed track is put into the bank,
+// and new track whose appearance is within maxCosineDistance of some bank entry gets identifier of that entry,
+// no matter where it appears (e.g. after long occlusion). Entries older than maxAge are dropped (zero means no limit).
+// Bank could be shared between trackers. Nil bank disables recovery
+func (tracker *SimpleTracker) SetReIDRecovery(bank *FeatureBank, maxCosineDistance float64, maxAge time.Duration) {
+	tracker.reid = reidRecovery{
+		bank:              bank,
+		maxCosineDistance: maxCosineDistance,
+		maxAge:            maxAge,
+	}
+}
+
+// GetFeatureBank returns bank which is used for appearance-based recovery (nil if recovery is disabled)
+func (tracker *SimpleTracker) GetFeatureBank() *FeatureBank {
+	return tracker.reid.bank
+}
+
+// deposit puts appearance of the removed track into the bank
+func (tracker *SimpleTracker) deposit(track *SimpleBlob) {
+	if tracker.reid.bank == nil || !track.confirmed {
+		return
+	}
+	features := track.gallery
+	if len(features) == 0 && len(track.feature) > 0 {
+		features = [][]float32{track.feature}
+	}
+	tracker.reid.bank.Add(FeatureBankEntry{
+		TrackID:   track.id,
+		Features:  append([][]float32{}, features...),
+		RemovedAt: tracker.frameTime,
+	})
+}
+
+// recover searches bank for removed track which looks like new object.
+// If it is found, it is taken out of the bank and its identifier is returned
+func (tracker *SimpleTracker) recover(newObject *SimpleBlob) (uuid.UUID, bool) {
+	if tracker.reid.bank == nil || len(newObject.feature) == 0 {
+		return uuid.UUID{}, false
+	}
+	if tracker.reid.maxAge > 0 {
+		tracker.reid.bank.Expire(tracker.frameTime.Add(-tracker.reid.maxAge))
+	}
+	entry, _, ok := tracker.reid.bank.Match(newObject.feature, tracker.reid.maxCosineDistance)
+	if !ok {
+		return uuid.UUID{}, false
+	}
+	if _, exists := tracker.storage.get(entry.TrackID); exists {
+		return uuid.UUID{}, false
+	}
+	if !tracker.reid.bank.Remove(entry.TrackID) {
+		// Entry has been taken by another tracker sharing the bank
+		return uuid.UUID{}, false
+	}
+	if tracker.featureBudget > 0 {
+		newObject.gallery = append(newObject.gallery, entry.Features...)
+		if len(newObject.gallery) > tracker.featureBudget {
+			newObject.gallery = newObject.gallery[len(newObject.gallery)-tracker.featureBudget:]
+		}
+	}
+	return entry.TrackID, true
+}
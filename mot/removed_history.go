@@ -0,0 +1,48 @@
+package mot
+
+// removedHistory is capped list of removed tracks (oldest first)
+type removedHistory struct {
+	capacity int
+	tracks   []*SimpleBlob
+}
+
+// push appends track to the history dropping the oldest one when capacity is exceeded
+func (history *removedHistory) push(track *SimpleBlob) {
+	if history.capacity <= 0 {
+		return
+	}
+	if len(history.tracks) >= history.capacity {
+		n := copy(history.tracks, history.tracks[len(history.tracks)-history.capacity+1:])
+		for i := n; i < len(history.tracks); i++ {
+			history.tracks[i] = nil
+		}
+		history.tracks = history.tracks[:n]
+	}
+	history.tracks = append(history.tracks, track)
+}
+
+// SetRemovedHistory enables keeping of up to capacity tracks which have been removed from tracker
+// (confirmed ones only), so their trajectories are not lost. Zero disables the history and drops kept tracks.
+// Use SetOnTrackRemoved if removed tracks need to be processed immediately instead
+func (tracker *SimpleTracker) SetRemovedHistory(capacity int) {
+	if capacity < 0 {
+		capacity = 0
+	}
+	tracker.removed.capacity = capacity
+	if len(tracker.removed.tracks) > capacity {
+		tracker.removed.tracks = append([]*SimpleBlob{}, tracker.removed.tracks[len(tracker.removed.tracks)-capacity:]...)
+	}
+}
+
+// GetRemovedTracks returns copy of removed tracks history (ordered by removal time, the oldest first)
+func (tracker *SimpleTracker) GetRemovedTracks() []*SimpleBlob {
+	return append([]*SimpleBlob{}, tracker.removed.tracks...)
+}
+
+// ClearRemovedTracks empties removed tracks history
+func (tracker *SimpleTracker) ClearRemovedTracks() {
+	for i := range tracker.removed.tracks {
+		tracker.removed.tracks[i] = nil
+	}
+	tracker.removed.tracks = tracker.removed.tracks[:0]
+}
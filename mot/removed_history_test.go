@@ -0,0 +1,41 @@
+package mot
+
+import (
+	"testing"
+)
+
+func TestRemovedHistory(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(1), WithRemovedHistory(2))
+	blobs := make([]*SimpleBlob, 0, 3)
+	for i := 0; i < 3; i++ {
+		blob := NewSimpleBlob(Rectangle{X: 100.0 * float64(i), Y: 10.0, Width: 20.0, Height: 20.0})
+		blobs = append(blobs, blob)
+		err := tracker.MatchObjects([]*SimpleBlob{blob})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	for i := 0; i < 3; i++ {
+		err := tracker.MatchObjects([]*SimpleBlob{})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	removed := tracker.GetRemovedTracks()
+	if len(removed) != 2 {
+		t.Errorf("incorrect number of removed tracks: %d, expected: %d", len(removed), 2)
+		return
+	}
+	if removed[0] != blobs[1] || removed[1] != blobs[2] {
+		t.Errorf("incorrect removed tracks: %v, expected the two latest ones: %v", removed, blobs[1:])
+	}
+	if len(removed[1].GetTrack()) == 0 {
+		t.Errorf("trajectory of removed track should be kept")
+	}
+	tracker.ClearRemovedTracks()
+	if len(tracker.GetRemovedTracks()) != 0 {
+		t.Errorf("incorrect number of removed tracks after clear: %d, expected: %d", len(tracker.GetRemovedTracks()), 0)
+	}
+}
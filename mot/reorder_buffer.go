@@ -0,0 +1,122 @@
+package mot
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TimedMatcher is the interface which trackers implement to accept timestamped frames
+type TimedMatcher interface {
+	MatchObjectsWithResultAt(timestamp time.Time, newObjects []*SimpleBlob) (*MatchResult, error)
+}
+
+var (
+	_ TimedMatcher = (*SimpleTracker)(nil)
+	_ TimedMatcher = (*TiledTracker)(nil)
+)
+
+// ReorderedFrame is the outcome of processing frame which has been released by ReorderBuffer
+type ReorderedFrame struct {
+	// Timestamp of the frame
+	Timestamp time.Time
+	// Association report. It is nil if Err is not nil
+	Result *MatchResult
+	// Error which occurred during matching
+	Err error
+}
+
+// timedFrame is frame which is held by ReorderBuffer
+type timedFrame struct {
+	timestamp  time.Time
+	newObjects []*SimpleBlob
+}
+
+// ReorderBuffer holds back a few of the latest frames and passes them to tracker in order of timestamps,
+// so slightly out-of-order frames (e.g. produced by multi-threaded decoders) don't corrupt Kalman filters state.
+// Frames which arrive after later frames have been passed to tracker already are rejected.
+// It is safe for concurrent use
+type ReorderBuffer struct {
+	mu      sync.Mutex
+	tracker TimedMatcher
+	size    int
+	// Held frames (sorted by timestamp)
+	pending []timedFrame
+	// Timestamp of the latest frame which has been passed to tracker
+	released   time.Time
+	lateFrames int
+}
+
+// NewReorderBuffer creates buffer which holds back up to size frames (so it adds latency of size frames).
+// Zero size passes frames immediately and only rejects late ones
+func NewReorderBuffer(tracker TimedMatcher, size int) *ReorderBuffer {
+	if size < 0 {
+		size = 0
+	}
+	return &ReorderBuffer{
+		tracker: tracker,
+		size:    size,
+		pending: make([]timedFrame, 0, size+1),
+	}
+}
+
+// Push adds frame to the buffer and returns frames which have been passed to tracker as the result (oldest first).
+// Returns ErrLateFrame if frame is older than the latest frame passed to tracker already (such frame is dropped)
+func (buffer *ReorderBuffer) Push(timestamp time.Time, newObjects []*SimpleBlob) ([]ReorderedFrame, error) {
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+	if !buffer.released.IsZero() && timestamp.Before(buffer.released) {
+		buffer.lateFrames++
+		return nil, errors.Wrapf(ErrLateFrame, "Frame at %s arrived after frame at %s", timestamp.Format(time.RFC3339Nano), buffer.released.Format(time.RFC3339Nano))
+	}
+	// Frames with equal timestamps keep order of arrival
+	idx := sort.Search(len(buffer.pending), func(i int) bool {
+		return buffer.pending[i].timestamp.After(timestamp)
+	})
+	buffer.pending = append(buffer.pending, timedFrame{})
+	copy(buffer.pending[idx+1:], buffer.pending[idx:])
+	buffer.pending[idx] = timedFrame{timestamp: timestamp, newObjects: newObjects}
+	return buffer.release(len(buffer.pending) - buffer.size), nil
+}
+
+// Flush passes all held frames to tracker (e.g. at the end of stream) and returns their results
+func (buffer *ReorderBuffer) Flush() []ReorderedFrame {
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+	return buffer.release(len(buffer.pending))
+}
+
+// release passes given number of the oldest held frames to tracker
+func (buffer *ReorderBuffer) release(n int) []ReorderedFrame {
+	if n <= 0 {
+		return nil
+	}
+	frames := make([]ReorderedFrame, 0, n)
+	for _, frame := range buffer.pending[:n] {
+		result, err := buffer.tracker.MatchObjectsWithResultAt(frame.timestamp, frame.newObjects)
+		frames = append(frames, ReorderedFrame{Timestamp: frame.timestamp, Result: result, Err: err})
+		buffer.released = frame.timestamp
+	}
+	remaining := copy(buffer.pending, buffer.pending[n:])
+	for i := remaining; i < len(buffer.pending); i++ {
+		buffer.pending[i] = timedFrame{}
+	}
+	buffer.pending = buffer.pending[:remaining]
+	return frames
+}
+
+// Len returns number of held frames
+func (buffer *ReorderBuffer) Len() int {
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+	return len(buffer.pending)
+}
+
+// LateFrames returns number of frames which have been dropped because of arriving too late
+func (buffer *ReorderBuffer) LateFrames() int {
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+	return buffer.lateFrames
+}
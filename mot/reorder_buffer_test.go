@@ -0,0 +1,52 @@
+package mot
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReorderBuffer(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0))
+	buffer := NewReorderBuffer(tracker, 2)
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	// Frames 1 and 2 are swapped by decoder
+	order := []int{0, 2, 1, 3, 4, 5}
+	released := make([]ReorderedFrame, 0, len(order))
+	for _, frame := range order {
+		detection := NewSimpleBlob(Rectangle{X: 10.0 + 10.0*float64(frame), Y: 10.0, Width: 20.0, Height: 40.0})
+		frames, err := buffer.Push(start.Add(time.Duration(frame)*time.Second), []*SimpleBlob{detection})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		released = append(released, frames...)
+	}
+	if buffer.Len() != 2 {
+		t.Errorf("incorrect number of held frames: %v, expected: %v", buffer.Len(), 2)
+	}
+	released = append(released, buffer.Flush()...)
+	if len(released) != len(order) {
+		t.Errorf("incorrect number of released frames: %v, expected: %v", len(released), len(order))
+		return
+	}
+	for i, frame := range released {
+		if frame.Err != nil {
+			t.Error(frame.Err)
+			return
+		}
+		if !frame.Timestamp.Equal(start.Add(time.Duration(i) * time.Second)) {
+			t.Errorf("incorrect order of released frame %d: %v", i, frame.Timestamp)
+		}
+	}
+	if len(tracker.Objects) != 1 {
+		t.Errorf("incorrect number of tracks: %v, expected: %v", len(tracker.Objects), 1)
+	}
+	_, err := buffer.Push(start.Add(2*time.Second), nil)
+	if !errors.Is(err, ErrLateFrame) {
+		t.Errorf("incorrect error: %v, expected: %v", err, ErrLateFrame)
+	}
+	if buffer.LateFrames() != 1 {
+		t.Errorf("incorrect number of late frames: %v, expected: %v", buffer.LateFrames(), 1)
+	}
+}
@@ -0,0 +1,83 @@
+package mot
+
+import "github.com/google/uuid"
+
+// purgatoryEntry is removed track which could still give its identifier to new track
+type purgatoryEntry struct {
+	track     *SimpleBlob
+	removedAt int
+}
+
+// purgatory keeps recently removed tracks for time-limited window
+type purgatory struct {
+	window  int
+	entries []purgatoryEntry
+}
+
+// SetResurrectionWindow enables identity resurrection: confirmed tracks which have been removed are kept for given number of frames
+// and new track which appears close enough to one of them (see distance threshold) gets the old identifier instead of new one.
+// It reduces identifiers churn when object is lost for a bit longer than maxNoMatch. Zero disables resurrection
+func (tracker *SimpleTracker) SetResurrectionWindow(frames int) {
+	if frames < 0 {
+		frames = 0
+	}
+	tracker.purgatory.window = frames
+	if frames == 0 {
+		tracker.purgatory.entries = tracker.purgatory.entries[:0]
+	}
+}
+
+// GetResurrectionWindow returns number of frames during which removed tracks could be resurrected
+func (tracker *SimpleTracker) GetResurrectionWindow() int {
+	return tracker.purgatory.window
+}
+
+// bury puts removed track into purgatory
+func (tracker *SimpleTracker) bury(track *SimpleBlob) {
+	if tracker.purgatory.window <= 0 || !track.confirmed {
+		return
+	}
+	tracker.purgatory.entries = append(tracker.purgatory.entries, purgatoryEntry{track: track, removedAt: tracker.counters.framesProcessed})
+}
+
+// expirePurgatory drops tracks which have been removed too long ago
+func (tracker *SimpleTracker) expirePurgatory() {
+	entries := tracker.purgatory.entries
+	n := 0
+	for _, entry := range entries {
+		if tracker.counters.framesProcessed-entry.removedAt > tracker.purgatory.window {
+			continue
+		}
+		entries[n] = entry
+		n++
+	}
+	for i := n; i < len(entries); i++ {
+		entries[i] = purgatoryEntry{}
+	}
+	tracker.purgatory.entries = entries[:n]
+}
+
+// resurrect searches purgatory for the closest removed track which is consistent with new object.
+// If it is found, it is taken out of purgatory and its identifier is returned
+func (tracker *SimpleTracker) resurrect(newObject *SimpleBlob) (uuid.UUID, bool) {
+	entries := tracker.purgatory.entries
+	bestIdx := -1
+	bestDistance := 0.0
+	for i, entry := range entries {
+		distance := tracker.distanceBetween(newObject, entry.track)
+		if !tracker.withinGate(newObject, entry.track, distance) {
+			continue
+		}
+		if bestIdx < 0 || distance < bestDistance {
+			bestIdx, bestDistance = i, distance
+		}
+	}
+	if bestIdx < 0 {
+		return uuid.UUID{}, false
+	}
+	id := entries[bestIdx].track.id
+	copy(entries[bestIdx:], entries[bestIdx+1:])
+	entries[len(entries)-1] = purgatoryEntry{}
+	tracker.purgatory.entries = entries[:len(entries)-1]
+	return id, true
+}
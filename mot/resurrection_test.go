@@ -0,0 +1,38 @@
+package mot
+
+import (
+	"testing"
+)
+
+func TestResurrection(t *testing.T) {
+	for _, window := range []int{0, 5} {
+		tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(1), WithResurrectionWindow(window))
+		blob := NewSimpleBlob(Rectangle{X: 10.0, Y: 10.0, Width: 20.0, Height: 20.0})
+		err := tracker.MatchObjects([]*SimpleBlob{blob})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		for frame := 0; frame < 3; frame++ {
+			err = tracker.MatchObjects([]*SimpleBlob{})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		if len(tracker.Objects) != 0 {
+			t.Errorf("track should be removed (window=%d): %d tracks left", window, len(tracker.Objects))
+			return
+		}
+		reappeared := NewSimpleBlob(Rectangle{X: 12.0, Y: 10.0, Width: 20.0, Height: 20.0})
+		result, err := tracker.MatchObjectsWithResult([]*SimpleBlob{reappeared})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		expected := window > 0
+		if (reappeared.GetID() == blob.GetID()) != expected || result.Created[0].Resurrected != expected {
+			t.Errorf("incorrect resurrection (window=%d): %v, expected: %v", window, reappeared.GetID() == blob.GetID(), expected)
+		}
+	}
+}
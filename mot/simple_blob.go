@@ -2,8 +2,8 @@ package mot
 
 import (
 	"math"
+	"time"
 
-	kalman_filter "github.com/LdDl/kalman-filter"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 )
@@ -14,6 +14,9 @@ type Blobie interface {
 	IncNoMatch()
 	DecNoMatch()
 	PredictNextPositionNaive(depth int)
+	Activate()
+	Deactivate()
+	IsActive() bool
 }
 
 type SimpleBlob struct {
@@ -22,11 +25,40 @@ type SimpleBlob struct {
 	currentCenter         Point
 	predictedNextPosition Point
 	track                 []Point
-	maxTrackLen           int
-	active                bool
-	noMatchTimes          int
-	diagonal              float64
-	tracker               *kalman_filter.Kalman2D
+	// Frames and timestamps of track points (aligned with track)
+	trackStamps  []FrameStamp
+	maxTrackLen  int
+	active       bool
+	noMatchTimes int
+	diagonal     float64
+	tracker      *kalmanFilter
+	// Confidence of the latest matched detection (decays while object is not matched)
+	confidence float64
+	// Number of consecutive frames in which blob has been matched (including the frame of registration)
+	consecutiveMatches int
+	// Total number of frames in which blob has been matched (including the frame of registration)
+	matches int
+	// Bounding boxes of the latest track points. It is recorded only if tracker has finalization callback
+	bboxHistory []Rectangle
+	// Whether blob has been matched enough times to be reported by tracker
+	confirmed bool
+	// Frame on which blob has been registered as track and the latest frame on which it has been matched
+	createdAt  FrameStamp
+	lastSeenAt FrameStamp
+	// Whether blob has got identifier of removed track (via resurrection or ReID recovery)
+	revived bool
+	// Appearance feature (e.g. ReID embedding). Empty if appearance is unknown
+	feature []float32
+	// Recent appearance features (budget-limited by tracker)
+	gallery [][]float32
+	// Object class reported by detector (e.g. "car"). Empty if class is unknown
+	class string
+	// Compact identifier for displaying purposes. Zero means that it has not been assigned
+	displayID int
+	// Whether blob is exempt from max no match cleanup
+	pinned bool
+	// Block of tracker-owned arena which backs the track (nil if track is allocated separately)
+	arenaBlock []Point
 }
 
 func NewSimpleBlobWithCenterTime(currentCenter Point, currentBbox Rectangle, dt float64) *SimpleBlob {
@@ -38,7 +70,7 @@ func NewSimpleBlobWithCenterTime(currentCenter Point, currentBbox Rectangle, dt
 	stdDevA := 2.0
 	stdDevMx := 0.1
 	stdDevMy := 0.1
-	kf := kalman_filter.NewKalman2D(dt, ux, uy, stdDevA, stdDevMx, stdDevMy, kalman_filter.WithState2D(currentCenter.X, currentCenter.Y))
+	kf := newKalmanFilter(dt, ux, uy, stdDevA, stdDevMx, stdDevMy, currentCenter.X, currentCenter.Y)
 	blob := SimpleBlob{
 		id:                    uuid.New(),
 		currentBBox:           currentBbox,
@@ -50,14 +82,17 @@ func NewSimpleBlobWithCenterTime(currentCenter Point, currentBbox Rectangle, dt
 		noMatchTimes:          0,
 		diagonal:              diagonal,
 		tracker:               kf,
+		confidence:            1.0,
+		consecutiveMatches:    1,
+		matches:               1,
 	}
 	blob.track = append(blob.track, blob.currentCenter)
+	blob.trackStamps = append(blob.trackStamps, FrameStamp{})
 	return &blob
 }
 
 func NewSimpleBlobWithTime(currentBbox Rectangle, dt float64) *SimpleBlob {
-	centerX := currentBbox.X + currentBbox.Width/2.0
-	centerY := currentBbox.Y + currentBbox.Height/2.0
+	center := currentBbox.Center()
 	diagonal := math.Sqrt(math.Pow(currentBbox.Width, 2) + math.Pow(currentBbox.Height, 2))
 
 	/* Kalman filter props */
@@ -66,11 +101,11 @@ func NewSimpleBlobWithTime(currentBbox Rectangle, dt float64) *SimpleBlob {
 	stdDevA := 2.0
 	stdDevMx := 0.1
 	stdDevMy := 0.1
-	kf := kalman_filter.NewKalman2D(dt, ux, uy, stdDevA, stdDevMx, stdDevMy, kalman_filter.WithState2D(centerX, centerY))
+	kf := newKalmanFilter(dt, ux, uy, stdDevA, stdDevMx, stdDevMy, center.X, center.Y)
 	blob := SimpleBlob{
 		id:                    uuid.New(),
 		currentBBox:           currentBbox,
-		currentCenter:         Point{X: centerX, Y: centerY},
+		currentCenter:         center,
 		predictedNextPosition: Point{X: 0, Y: 0},
 		track:                 make([]Point, 0, 150),
 		maxTrackLen:           150,
@@ -78,8 +113,12 @@ func NewSimpleBlobWithTime(currentBbox Rectangle, dt float64) *SimpleBlob {
 		noMatchTimes:          0,
 		diagonal:              diagonal,
 		tracker:               kf,
+		confidence:            1.0,
+		consecutiveMatches:    1,
+		matches:               1,
 	}
 	blob.track = append(blob.track, blob.currentCenter)
+	blob.trackStamps = append(blob.trackStamps, FrameStamp{})
 	return &blob
 }
 
@@ -97,6 +136,11 @@ func (blob *SimpleBlob) Deactivate() {
 	blob.active = false
 }
 
+// IsActive returns whether blob has been matched on the last frame. Tracks which are not active are coasting on predictions
+func (blob *SimpleBlob) IsActive() bool {
+	return blob.active
+}
+
 // GetID returns blob's indentifier
 func (blob *SimpleBlob) GetID() uuid.UUID {
 	return blob.id
@@ -107,6 +151,21 @@ func (blob *SimpleBlob) SetID(newID uuid.UUID) {
 	blob.id = newID
 }
 
+// GetDisplayID returns blob's compact display identifier. Zero means that it has not been assigned (see SimpleTracker.SetDisplayIDs)
+func (blob *SimpleBlob) GetDisplayID() int {
+	return blob.displayID
+}
+
+// GetClass returns blob's object class. Empty class means that it is unknown
+func (blob *SimpleBlob) GetClass() string {
+	return blob.class
+}
+
+// SetClass sets blob's object class. When track is matched with detection which has class, track takes detection's class
+func (blob *SimpleBlob) SetClass(class string) {
+	blob.class = class
+}
+
 // GetCenter returns blob's current center
 func (blob *SimpleBlob) GetCenter() Point {
 	return blob.currentCenter
@@ -117,6 +176,19 @@ func (blob *SimpleBlob) GetBBox() Rectangle {
 	return blob.currentBBox
 }
 
+// GetPredictedCenter returns blob's center predicted by Kalman filter during the last prediction step
+func (blob *SimpleBlob) GetPredictedCenter() Point {
+	return blob.predictedNextPosition
+}
+
+// GetPredictedBBox returns blob's current bounding box moved to the predicted center
+func (blob *SimpleBlob) GetPredictedBBox() Rectangle {
+	bbox := blob.currentBBox
+	bbox.X += blob.predictedNextPosition.X - blob.currentCenter.X
+	bbox.Y += blob.predictedNextPosition.Y - blob.currentCenter.Y
+	return bbox
+}
+
 // GetDiagonal returns blob's estimated diagonal
 func (blob *SimpleBlob) GetDiagonal() float64 {
 	return blob.diagonal
@@ -127,6 +199,46 @@ func (blob *SimpleBlob) GetTrack() []Point {
 	return blob.track
 }
 
+// GetTrackStamps returns frames and timestamps of track points: i-th stamp belongs to i-th point of GetTrack.
+// Stamps are filled in by tracker, so they are zero for points which have been added outside of MatchObjects.
+// Be careful: this is not copy of stamps, but reference to them
+func (blob *SimpleBlob) GetTrackStamps() []FrameStamp {
+	return blob.trackStamps
+}
+
+// GetCreatedAt returns frame and time when blob has been registered as track. It is zero for blobs which are not registered
+func (blob *SimpleBlob) GetCreatedAt() FrameStamp {
+	return blob.createdAt
+}
+
+// GetLastSeenAt returns frame and time when blob has been matched with detection for the last time (or registered)
+func (blob *SimpleBlob) GetLastSeenAt() FrameStamp {
+	return blob.lastSeenAt
+}
+
+// GetMatches returns total number of frames in which blob has been matched (including the frame of registration)
+func (blob *SimpleBlob) GetMatches() int {
+	return blob.matches
+}
+
+// GetDwellTime returns time between registration of blob and its latest match
+func (blob *SimpleBlob) GetDwellTime() time.Duration {
+	return blob.lastSeenAt.Timestamp.Sub(blob.createdAt.Timestamp)
+}
+
+// stampLastPoint sets stamp of the latest track point
+func (blob *SimpleBlob) stampLastPoint(stamp FrameStamp) {
+	if n := len(blob.trackStamps); n > 0 {
+		blob.trackStamps[n-1] = stamp
+	}
+}
+
+// GetTrackBounds returns the smallest rectangle which contains blob's track.
+// Note: track consists of centers, so extend it by bounding box size if the whole object needs to be cropped
+func (blob *SimpleBlob) GetTrackBounds() Rectangle {
+	return TrackBounds(blob.track)
+}
+
 // GetMaxTrackLen returns blob's max track length
 func (blob *SimpleBlob) GetMaxTrackLen() int {
 	return blob.maxTrackLen
@@ -137,6 +249,27 @@ func (blob *SimpleBlob) SetMaxTrackLen(newMaxTrackLen int) {
 	blob.maxTrackLen = newMaxTrackLen
 }
 
+// GetConfidence returns confidence of the latest matched detection. For tracks which are not matched it decays over time
+func (blob *SimpleBlob) GetConfidence() float64 {
+	return blob.confidence
+}
+
+// SetConfidence sets blob's confidence (e.g. detection score). Default is 1.0
+func (blob *SimpleBlob) SetConfidence(confidence float64) {
+	blob.confidence = confidence
+}
+
+// IsConfirmed returns whether blob has been matched in enough consecutive frames to be reported by tracker.
+// See SimpleTracker.SetMinConsecutiveMatches
+func (blob *SimpleBlob) IsConfirmed() bool {
+	return blob.confirmed
+}
+
+// IsPinned returns whether blob is exempt from max no match cleanup. See SimpleTracker.PinTrack
+func (blob *SimpleBlob) IsPinned() bool {
+	return blob.pinned
+}
+
 // GetNoMatchTimes returns blob's no match times
 func (blob *SimpleBlob) GetNoMatchTimes() int {
 	return blob.noMatchTimes
@@ -159,8 +292,8 @@ func (blob *SimpleBlob) DistanceToPredicted(otherBlob *SimpleBlob) float64 {
 
 // PredictNextPosition execute Kalman filter's first step but without re-evaluating state vector based on Kalman gain
 func (blob *SimpleBlob) PredictNextPosition() {
-	blob.tracker.Predict()
-	stateX, stateY := blob.tracker.GetState()
+	blob.tracker.predict()
+	stateX, stateY := blob.tracker.position()
 	blob.predictedNextPosition.X = stateX
 	blob.predictedNextPosition.Y = stateY
 }
@@ -172,12 +305,12 @@ func (blob *SimpleBlob) Update(newBlob *SimpleBlob) error {
 	blob.currentBBox = newBlob.currentBBox
 
 	// Smooth center via Kalman filter.
-	err := blob.tracker.Update(float64(blob.currentCenter.X), float64(blob.currentCenter.Y))
+	err := blob.tracker.update(float64(blob.currentCenter.X), float64(blob.currentCenter.Y))
 	if err != nil {
-		return errors.Wrap(err, "Can't update object tracker")
+		return errors.Wrap(withSentinel(ErrKalmanUpdate, err), "Can't update object tracker")
 	}
 	// Update center and re-evaluate bounding box
-	stateX, stateY := blob.tracker.GetState()
+	stateX, stateY := blob.tracker.position()
 	oldX := blob.currentCenter.X
 	oldY := blob.currentCenter.Y
 	blob.currentCenter.X = stateX
@@ -190,12 +323,58 @@ func (blob *SimpleBlob) Update(newBlob *SimpleBlob) error {
 	blob.currentBBox.Height -= diffY
 	// Update remaining properties
 	blob.diagonal = newBlob.diagonal
+	blob.confidence = newBlob.confidence
+	if len(newBlob.feature) > 0 {
+		blob.feature = newBlob.feature
+	}
+	if newBlob.class != "" {
+		blob.class = newBlob.class
+	}
 	blob.active = true
 	blob.noMatchTimes = 0
+	blob.consecutiveMatches++
+	blob.matches++
 	// Update track
-	blob.track = append(blob.track, blob.currentCenter)
-	if len(blob.track) > blob.maxTrackLen {
-		blob.track = blob.track[1:]
-	}
+	blob.appendToTrack(blob.currentCenter)
 	return nil
 }
+
+// minMeasurementWeight bounds measurement weight from below so measurement noise stays finite
+const minMeasurementWeight = 1e-3
+
+// UpdateWeighted is the same as Update, but Kalman filter's correction is weighted by the given value in (0; 1] range:
+// measurement noise is scaled by 1/weight for this update only, so measurements with lower weight pull the state less
+func (blob *SimpleBlob) UpdateWeighted(newBlob *SimpleBlob, weight float64) error {
+	if weight >= 1 || math.IsNaN(weight) {
+		return blob.Update(newBlob)
+	}
+	weight = math.Max(weight, minMeasurementWeight)
+	original := blob.tracker.r
+	for i := range blob.tracker.r {
+		blob.tracker.r[i] /= weight
+	}
+	defer func() {
+		blob.tracker.r = original
+	}()
+	return blob.Update(newBlob)
+}
+
+// appendToTrack adds point to the track keeping its length not greater than max track length.
+// Points are shifted in-place, so the underlying array is not reallocated once the track is full.
+// Stamp of the new point is zero until tracker sets it
+func (blob *SimpleBlob) appendToTrack(pt Point) {
+	blob.track = appendBounded(blob.track, pt, blob.maxTrackLen)
+	blob.trackStamps = appendBounded(blob.trackStamps, FrameStamp{}, blob.maxTrackLen)
+}
+
+// appendBounded appends value to the slice keeping its length not greater than maxLen by dropping the oldest values
+func appendBounded[T any](values []T, value T, maxLen int) []T {
+	if maxLen <= 0 {
+		return values[:0]
+	}
+	if len(values) >= maxLen {
+		n := copy(values, values[len(values)-maxLen+1:])
+		return append(values[:n], value)
+	}
+	return append(values, value)
+}
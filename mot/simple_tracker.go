@@ -1,7 +1,9 @@
 package mot
 
 import (
+	"context"
 	"math"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
@@ -9,106 +11,728 @@ import (
 
 // SimpleTracker is naive implementation of Multi-object tracker (MOT)
 type SimpleTracker struct {
-	// Main storage
+	// Lookup view of confirmed tracks. It is kept in sync by the tracker, so do not modify it directly
 	Objects map[uuid.UUID]*SimpleBlob
+	// Main storage (tracks are ordered by registration time)
+	storage trackStorage
 	// Threshold distance (most of time in pixels). Default 30.0
 	minDistThreshold float64
 	// Max no match (max number of frames when object could not be found again). Default is 75
 	maxNoMatch int
+	// Should cost matrices be captured for debugging purposes
+	debug bool
+	// Debug information for the last MatchObjects call
+	lastDebugInfo *DebugInfo
+	// Accumulated statistics
+	counters trackerCounters
+	// Spatial index over tracks centers
+	indexType SpatialIndex
+	index     spatialIndex
+	// Buffers which are reused between MatchObjects calls to reduce allocations
+	buffers simpleTrackerBuffers
+	// Max number of tracks to keep. Zero means no limit
+	maxObjects int
+	// How to choose tracks to be removed when there are more than maxObjects of them
+	evictionPolicy EvictionPolicy
+	// Storage for tracks history. Nil means that each blob keeps its own history
+	arena *pointArena
+	// Function which receives durations of MatchObjects stages
+	timingCallback func(timings FrameTimings)
+	// Should pprof labels be set for MatchObjects stages
+	profilerLabels bool
+	// Stages tracking for the current frame
+	stages frameStages
+	// Multiplier which is applied to confidence of unmatched tracks each frame. Default is 1.0 (no decay)
+	confidenceDecay float64
+	// Whether Kalman filter's correction is weighted by detection confidence
+	scoreWeightedUpdate bool
+	// Filter which is applied to detections before association
+	filter detectionFilter
+	// Which centers of existing tracks are used during matching
+	distanceMode DistanceMode
+	// Factors of track's diagonal and speed which are added to minDistThreshold for each track
+	diagonalGateScale float64
+	speedGateScale    float64
+	// Number of consecutive matches which are needed to confirm new track. Default is 1
+	minConsecutiveMatches int
+	// Track lifecycle callbacks
+	callbacks trackCallbacks
+	// History of removed tracks
+	removed removedHistory
+	// Recently removed tracks which could be resurrected
+	purgatory purgatory
+	// Frame bounds for border-exit termination (zero size disables it)
+	frameBounds Rectangle
+	// Subscribers to track lifecycle events
+	eventHandlers []func(event Event)
+	// Time of the frame which is being processed and time of the previous one
+	frameTime     time.Time
+	prevFrameTime time.Time
+	// Expected time between frames. Zero means that it is estimated from frames timestamps
+	frameInterval time.Duration
+	// Whether tracking is paused
+	suspended bool
+	// Min ratio of detection's area to track's area which does not trigger occlusion-freeze mode. Zero disables the mode
+	occlusionFreezeRatio float64
+	// Max number of appearance features in gallery of each track. Zero disables galleries
+	featureBudget int
+	// Max cosine distance between appearance features of detection and track. Zero disables the gate
+	appearanceGate float64
+	// Custom association cost. Nil means that distance mode is used
+	costFunction CostFunction
+	// Max time since the last match after which track is removed. Zero disables the check
+	maxIdleTime time.Duration
+	// Heuristics which flag probable identity switches
+	idMonitor idSwitchMonitor
+	// Number of dropped frames which has been reported for the next frame
+	pendingDrop int
+	// Whether dropped frames are detected from timestamps
+	dropCompensation bool
+	// Max duration of association. Zero means that there is no limit
+	latencyBudget time.Duration
+	// Time when processing of the current frame has started
+	frameStart time.Time
+	// Calibration of speed estimation. Nil means that speed is not estimated
+	speedCalibration *SpeedCalibration
+	// Lens distortion of the camera. Nil means that detections are already rectified
+	lensDistortion LensDistortion
+	// Normalization of detections appearance features
+	featureNormalization FeatureNormalization
+	// Appearance-based recovery of removed tracks
+	reid reidRecovery
+	// Function which receives complete record of each removed track
+	finalizer func(track FinalizedTrack)
+	// Allocator of compact display identifiers. Nil disables them
+	displayIDs *displayIDs
+	// Hooks which are called around MatchObjects and its stages
+	hooks []FrameHooks
 }
 
-// NewSimpleTrackerDefault creates default instance of SimpleTracker
-func NewSimpleTrackerDefault() *SimpleTracker {
-	return &SimpleTracker{
-		Objects:          make(map[uuid.UUID]*SimpleBlob),
-		minDistThreshold: 30.0,
-		maxNoMatch:       75,
+// simpleTrackerBuffers holds per-frame intermediate data
+type simpleTrackerBuffers struct {
+	// Flags for new objects which should be registered as new tracks (indexed as input slice)
+	toRegister      []bool
+	rejected        []bool
+	priorityQueue   distanceHeap
+	reservedObjects map[uuid.UUID]struct{}
+}
+
+// reset prepares buffers for the next frame
+func (buffers *simpleTrackerBuffers) reset(newObjectsNum int) {
+	if buffers.reservedObjects == nil {
+		buffers.reservedObjects = make(map[uuid.UUID]struct{})
+	}
+	if cap(buffers.toRegister) < newObjectsNum {
+		buffers.toRegister = make([]bool, newObjectsNum)
+	}
+	buffers.toRegister = buffers.toRegister[:newObjectsNum]
+	for i := range buffers.toRegister {
+		buffers.toRegister[i] = false
 	}
+	if cap(buffers.rejected) < newObjectsNum {
+		buffers.rejected = make([]bool, newObjectsNum)
+	}
+	buffers.rejected = buffers.rejected[:newObjectsNum]
+	for i := range buffers.rejected {
+		buffers.rejected[i] = false
+	}
+	for objectID := range buffers.reservedObjects {
+		delete(buffers.reservedObjects, objectID)
+	}
+	// Popped items are still referenced by the underlying array, so release them
+	queue := buffers.priorityQueue[:cap(buffers.priorityQueue)]
+	for i := range queue {
+		queue[i] = nil
+	}
+	buffers.priorityQueue = queue[:0]
 }
 
 // NewSimpleTracker creates new instance of SimpleTracker
+//
+// options - functional parameters (see WithMinDistThreshold, WithMaxNoMatch and others)
+func NewSimpleTracker(options ...func(*SimpleTracker)) *SimpleTracker {
+	tracker := &SimpleTracker{
+		Objects:               make(map[uuid.UUID]*SimpleBlob),
+		storage:               newTrackStorage(),
+		minDistThreshold:      30.0,
+		maxNoMatch:            75,
+		indexType:             SpatialIndexGrid,
+		confidenceDecay:       1.0,
+		minConsecutiveMatches: 1,
+	}
+	for _, o := range options {
+		o(tracker)
+	}
+	tracker.index = newSpatialIndex(tracker.indexType, tracker.minDistThreshold)
+	return tracker
+}
+
+// NewSimpleTrackerDefault creates default instance of SimpleTracker
+func NewSimpleTrackerDefault() *SimpleTracker {
+	return NewSimpleTracker()
+}
+
+// NewNewSimpleTracker creates new instance of SimpleTracker
+//
+// Deprecated: use NewSimpleTracker with WithMinDistThreshold and WithMaxNoMatch options instead
 func NewNewSimpleTracker(minDistThreshold float64, maxNoMatch int) *SimpleTracker {
-	return &SimpleTracker{
-		Objects:          make(map[uuid.UUID]*SimpleBlob),
-		minDistThreshold: minDistThreshold,
-		maxNoMatch:       maxNoMatch,
+	return NewSimpleTracker(WithMinDistThreshold(minDistThreshold), WithMaxNoMatch(maxNoMatch))
+}
+
+// GetMinDistThreshold returns threshold distance
+func (tracker *SimpleTracker) GetMinDistThreshold() float64 {
+	return tracker.minDistThreshold
+}
+
+// SetMinDistThreshold sets threshold distance. It is safe to call it between MatchObjects calls
+func (tracker *SimpleTracker) SetMinDistThreshold(minDistThreshold float64) {
+	tracker.minDistThreshold = minDistThreshold
+	// Grid cell size depends on the threshold
+	tracker.index = newSpatialIndex(tracker.indexType, minDistThreshold)
+}
+
+// GetConfidenceDecay returns multiplier which is applied to confidence of unmatched tracks each frame
+func (tracker *SimpleTracker) GetConfidenceDecay() float64 {
+	return tracker.confidenceDecay
+}
+
+// SetConfidenceDecay sets multiplier which is applied to confidence of unmatched tracks each frame (e.g. 0.9).
+// Value should be in [0; 1] range, 1.0 disables decay
+func (tracker *SimpleTracker) SetConfidenceDecay(decay float64) {
+	tracker.confidenceDecay = math.Max(0, math.Min(1, decay))
+}
+
+// SetMinConsecutiveMatches sets number of consecutive frames in which new track must be matched (including the frame of registration)
+// before it appears in Objects and GetTracks. Tracks which are lost before confirmation are removed immediately,
+// so flickering detections do not produce short-living tracks. Default is 1 (tracks are confirmed immediately)
+func (tracker *SimpleTracker) SetMinConsecutiveMatches(minConsecutiveMatches int) {
+	if minConsecutiveMatches < 1 {
+		minConsecutiveMatches = 1
+	}
+	tracker.minConsecutiveMatches = minConsecutiveMatches
+}
+
+// GetMinConsecutiveMatches returns number of consecutive matches which are needed to confirm new track
+func (tracker *SimpleTracker) GetMinConsecutiveMatches() int {
+	return tracker.minConsecutiveMatches
+}
+
+// SetScoreWeightedUpdate enables or disables weighting of Kalman filter's correction by detection confidence:
+// when enabled, detections with lower confidence pull matched tracks less. See SimpleBlob.UpdateWeighted
+func (tracker *SimpleTracker) SetScoreWeightedUpdate(enabled bool) {
+	tracker.scoreWeightedUpdate = enabled
+}
+
+// GetScoreWeightedUpdate returns whether Kalman filter's correction is weighted by detection confidence
+func (tracker *SimpleTracker) GetScoreWeightedUpdate() bool {
+	return tracker.scoreWeightedUpdate
+}
+
+// GetMaxNoMatch returns max number of frames when object could not be found again
+func (tracker *SimpleTracker) GetMaxNoMatch() int {
+	return tracker.maxNoMatch
+}
+
+// SetMaxNoMatch sets max number of frames when object could not be found again. It is safe to call it between MatchObjects calls
+func (tracker *SimpleTracker) SetMaxNoMatch(maxNoMatch int) {
+	tracker.maxNoMatch = maxNoMatch
+}
+
+// SetSpatialIndex sets type of spatial index which is used to find candidate tracks for each detection
+func (tracker *SimpleTracker) SetSpatialIndex(indexType SpatialIndex) {
+	tracker.indexType = indexType
+	tracker.index = newSpatialIndex(indexType, tracker.minDistThreshold)
+}
+
+// GetSpatialIndex returns type of spatial index which is used to find candidate tracks for each detection
+func (tracker *SimpleTracker) GetSpatialIndex() SpatialIndex {
+	return tracker.indexType
+}
+
+// GetTracks returns confirmed tracks ordered by registration time.
+// Be careful: when activation delay is disabled this is not copy of storage, but reference to it
+func (tracker *SimpleTracker) GetTracks() []*SimpleBlob {
+	if tracker.minConsecutiveMatches <= 1 {
+		return tracker.storage.tracks
+	}
+	tracks := make([]*SimpleBlob, 0, len(tracker.Objects))
+	for _, object := range tracker.storage.tracks {
+		if object.confirmed {
+			tracks = append(tracks, object)
+		}
+	}
+	return tracks
+}
+
+// GetActiveTracks returns confirmed tracks which have been matched on the last frame (ordered by registration time)
+func (tracker *SimpleTracker) GetActiveTracks() []*SimpleBlob {
+	tracks := make([]*SimpleBlob, 0, len(tracker.Objects))
+	for _, object := range tracker.storage.tracks {
+		if object.confirmed && object.IsActive() {
+			tracks = append(tracks, object)
+		}
+	}
+	return tracks
+}
+
+// addTrack registers new track. Track is visible in Objects only if it is confirmed
+func (tracker *SimpleTracker) addTrack(blob *SimpleBlob) {
+	tracker.attachToArena(blob)
+	tracker.storage.add(blob)
+	if blob.confirmed {
+		tracker.Objects[blob.id] = blob
+	}
+}
+
+// confirmTrack makes track visible if it has been matched in enough consecutive frames
+func (tracker *SimpleTracker) confirmTrack(blob *SimpleBlob) {
+	if blob.confirmed || blob.consecutiveMatches < tracker.minConsecutiveMatches {
+		return
+	}
+	tracker.publishTrack(blob)
+}
+
+// publishTrack makes track visible and notifies subscribers about it
+func (tracker *SimpleTracker) publishTrack(blob *SimpleBlob) {
+	blob.confirmed = true
+	tracker.assignDisplayID(blob)
+	tracker.Objects[blob.id] = blob
+	tracker.callbacks.onCreated(blob)
+	tracker.emit(EventTrackCreated, blob)
+}
+
+// onTrackRemoved is called for each track which has been removed from storage
+func (tracker *SimpleTracker) onTrackRemoved(blob *SimpleBlob) {
+	tracker.detachFromArena(blob)
+	delete(tracker.Objects, blob.id)
+	tracker.counters.tracksRemoved++
+	tracker.callbacks.onRemoved(blob)
+	if blob.confirmed {
+		tracker.emit(EventTrackRemoved, blob)
+		tracker.finalize(blob)
+		tracker.removed.push(blob)
+	}
+	tracker.releaseDisplayID(blob)
+	tracker.bury(blob)
+	tracker.deposit(blob)
+}
+
+// detachTrack removes track from the tracker without treating it as removed one
+func (tracker *SimpleTracker) detachTrack(id uuid.UUID) (*SimpleBlob, bool) {
+	blob, ok := tracker.storage.remove(id)
+	if !ok {
+		return nil, false
+	}
+	tracker.detachFromArena(blob)
+	delete(tracker.Objects, id)
+	return blob, true
+}
+
+// SetDebug enables or disables capturing of cost matrices during MatchObjects
+func (tracker *SimpleTracker) SetDebug(enabled bool) {
+	tracker.debug = enabled
+	if !enabled {
+		tracker.lastDebugInfo = nil
 	}
 }
 
+// GetDebugInfo returns cost matrices and assignment captured during the last MatchObjects call.
+// Returns nil if debug mode is disabled
+func (tracker *SimpleTracker) GetDebugInfo() *DebugInfo {
+	return tracker.lastDebugInfo
+}
+
+// Stats returns summary of tracker health
+func (tracker *SimpleTracker) Stats() TrackerStats {
+	activeTracks := 0
+	for _, object := range tracker.storage.tracks {
+		if object.confirmed && object.IsActive() {
+			activeTracks++
+		}
+	}
+	return tracker.counters.stats(activeTracks)
+}
+
+// MatchObjects matches new objects with existing ones
 func (tracker *SimpleTracker) MatchObjects(newObjects []*SimpleBlob) error {
-	for objectID := range tracker.Objects {
-		tracker.Objects[objectID].Deactivate() // Make sure that object is marked as deactivated
-		tracker.Objects[objectID].PredictNextPosition()
+	return tracker.matchObjects(context.Background(), time.Time{}, newObjects, nil)
+}
+
+// MatchObjectsCtx is the same as MatchObjects, but matching could be cancelled or bounded by deadline via context.
+// Context is checked before existing objects are updated, so cancelled call leaves them only predicted (as if nothing has been detected),
+// but keeps their no match counters untouched
+func (tracker *SimpleTracker) MatchObjectsCtx(ctx context.Context, newObjects []*SimpleBlob) error {
+	return tracker.matchObjects(ctx, time.Time{}, newObjects, nil)
+}
+
+// MatchObjectsWithResult matches new objects with existing ones and returns per-frame association report
+func (tracker *SimpleTracker) MatchObjectsWithResult(newObjects []*SimpleBlob) (*MatchResult, error) {
+	return tracker.MatchObjectsWithResultCtx(context.Background(), newObjects)
+}
+
+// MatchObjectsWithResultCtx is the same as MatchObjectsWithResult, but matching could be cancelled or bounded by deadline via context.
+// See MatchObjectsCtx for details
+func (tracker *SimpleTracker) MatchObjectsWithResultCtx(ctx context.Context, newObjects []*SimpleBlob) (*MatchResult, error) {
+	result := NewMatchResult()
+	err := tracker.matchObjects(ctx, time.Time{}, newObjects, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// matchObjects processes single frame. Zero timestamp means that the frame is stamped with the current time
+func (tracker *SimpleTracker) matchObjects(ctx context.Context, timestamp time.Time, newObjects []*SimpleBlob, result *MatchResult) error {
+	return tracker.matchFrame(ctx, timestamp, newObjects, result, false)
+}
+
+// matchFrame implements matchObjects. Prepared frame has been validated and moved to rectified coordinates already (see MatchSequence).
+// Tracker's state is not changed until the frame passes all checks, so rejected or cancelled frame leaves tracker untouched
+func (tracker *SimpleTracker) matchFrame(ctx context.Context, timestamp time.Time, newObjects []*SimpleBlob, result *MatchResult, prepared bool) error {
+	frameStart := time.Now()
+	if tracker.suspended {
+		return errors.Wrapf(ErrSuspended, "Can't match objects on frame %d", tracker.counters.framesProcessed)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if !prepared {
+		if err := validateBlobs(newObjects); err != nil {
+			return err
+		}
+		for _, hooks := range tracker.hooks {
+			if hooks.BeforeFrame != nil {
+				newObjects = hooks.BeforeFrame(newObjects)
+			}
+		}
+		// Hooks could have replaced detections, so they are validated again
+		if _, err := tracker.prepareFrame(newObjects); err != nil {
+			return err
+		}
+	}
+	pendingDrop := tracker.pendingDrop
+	tracker.pendingDrop = 0
+	tracker.frameStart = frameStart
+	tracker.counters.lastFrameDegraded = false
+	if timestamp.IsZero() {
+		timestamp = frameStart
+	}
+	tracker.prevFrameTime = tracker.frameTime
+	tracker.frameTime = timestamp
+	if result != nil {
+		result.Frame = tracker.counters.framesProcessed
+		result.Timestamp = timestamp
+	}
+	tracker.stages.begin(ctx, tracker.profilerLabels, tracker.hooks)
+	defer tracker.stages.leave()
+	tracker.stages.enter(StagePredict)
+	tracker.compensateDrop(tracker.droppedFrames(pendingDrop))
+	for _, object := range tracker.storage.tracks {
+		object.Deactivate() // Make sure that object is marked as deactivated
+		object.PredictNextPosition()
+	}
+	tracker.stages.enter(StageCostMatrix)
+	var debugStage *DebugStage
+	if tracker.debug {
+		trackIDs := make([]uuid.UUID, 0, tracker.storage.len())
+		for _, object := range tracker.storage.tracks {
+			trackIDs = append(trackIDs, object.id)
+		}
+		debugStage = newDebugStage("distance", trackIDs, len(newObjects))
+		tracker.lastDebugInfo = &DebugInfo{Stages: []*DebugStage{debugStage}}
+	}
+	tracker.buffers.reset(len(newObjects))
+	tracker.idMonitor.reset()
+	tracker.filterDetections(newObjects, result)
+	var err error
+	if debugStage == nil && len(newObjects) <= smallFrameLimit && tracker.storage.len() <= smallFrameLimit {
+		err = tracker.associateSmallFrame(ctx, newObjects, result)
+	} else {
+		err = tracker.associate(ctx, newObjects, result, debugStage)
+	}
+	if err != nil {
+		return err
+	}
+	if len(tracker.idMonitor.moves) > 0 {
+		tracker.checkSwaps()
+	}
+
+	tracker.stages.enter(StageCleanup)
+	reservedObjects := tracker.buffers.reservedObjects
+	for _, object := range tracker.storage.tracks {
+		if _, ok := reservedObjects[object.id]; ok {
+			continue
+		}
+		object.confidence *= tracker.confidenceDecay
+		if object.consecutiveMatches > 0 {
+			tracker.callbacks.onLost(object)
+			if object.confirmed {
+				tracker.emit(EventTrackLost, object)
+			}
+		}
+		object.consecutiveMatches = 0
+		if result != nil {
+			result.Unmatched = append(result.Unmatched, object.id)
+		}
+	}
+
+	if tracker.purgatory.window > 0 {
+		tracker.expirePurgatory()
+	}
+	for i, register := range tracker.buffers.toRegister {
+		if !register {
+			continue
+		}
+		newObject := newObjects[i]
+		if _, exists := tracker.storage.get(newObject.id); exists {
+			// Blob has been passed with identifier of existing track (e.g. the same blob has been passed twice), so it needs a new one
+			newObject.id = uuid.New()
+		}
+		resurrected := false
+		if len(tracker.purgatory.entries) > 0 {
+			var oldID uuid.UUID
+			if oldID, resurrected = tracker.resurrect(newObject); resurrected {
+				newObject.id = oldID
+				if tracker.reid.bank != nil {
+					tracker.reid.bank.Remove(oldID)
+				}
+			}
+		}
+		if !resurrected && tracker.reid.bank != nil {
+			var oldID uuid.UUID
+			if oldID, resurrected = tracker.recover(newObject); resurrected {
+				newObject.id = oldID
+			}
+		}
+		newObject.revived = resurrected
+		newObject.consecutiveMatches = 1
+		newObject.confirmed = false
+		newObject.Activate()
+		newObject.createdAt = tracker.frameStamp()
+		newObject.lastSeenAt = newObject.createdAt
+		newObject.stampLastPoint(newObject.createdAt)
+		tracker.recordBBox(newObject)
+		tracker.pushFeature(newObject)
+		tracker.confirmTrack(newObject)
+		tracker.addTrack(newObject)
+		tracker.counters.tracksCreated++
+		if result != nil {
+			result.Created = append(result.Created, CreatedTrack{TrackID: newObject.id, DetectionIndex: i, Resurrected: resurrected})
+		}
+	}
+	tracker.counters.matchesTotal += len(reservedObjects)
+
+	// Clean up existing data
+	tracker.storage.retain(func(object *SimpleBlob) bool {
+		if !object.confirmed && object.consecutiveMatches == 0 {
+			// Track has not been confirmed and has been lost already, so it is considered as noise
+			return false
+		}
+		if !object.active && tracker.exitedFrame(object) {
+			// Track has left the frame
+			return false
+		}
+		if tracker.maxIdleTime > 0 && !object.pinned && tracker.frameTime.Sub(object.lastSeenAt.Timestamp) > tracker.maxIdleTime {
+			// Track has not been seen for too long
+			return false
+		}
+		object.IncNoMatch()
+		// Remove object if it was not found for a long time
+		return object.pinned || object.GetNoMatchTimes() <= tracker.maxNoMatch
+	}, tracker.onTrackRemoved)
+	tracker.evictExcessTracks()
+	tracker.stages.leave()
+	tracker.counters.framesProcessed++
+	tracker.counters.lastFrameLatency = time.Since(frameStart)
+	timings := tracker.stages.timings
+	timings.Total = tracker.counters.lastFrameLatency
+	tracker.counters.lastFrameTimings = timings
+	if result != nil {
+		result.Timings = timings
+	}
+	if tracker.timingCallback != nil {
+		tracker.timingCallback(timings)
+	}
+	for _, hooks := range tracker.hooks {
+		if hooks.AfterFrame != nil {
+			hooks.AfterFrame(result)
+		}
+	}
+	return nil
+}
+
+// distanceBetween returns association distance between new object and existing one
+func (tracker *SimpleTracker) distanceBetween(newObject *SimpleBlob, object *SimpleBlob) float64 {
+	if tracker.costFunction != nil {
+		return tracker.customCost(newObject, object)
 	}
-	blobsToRegister := make(map[uuid.UUID]*SimpleBlob)
-	priorityQueue := make(distanceHeap, 0)
+	if !tracker.appearanceCompatible(newObject, object) {
+		return math.MaxFloat64
+	}
+	// Note: detections have not been predicted yet, so their center is compared with track's predicted one
+	switch tracker.distanceMode {
+	case DistancePredicted:
+		return euclideanDistance(newObject.currentCenter, object.predictedNextPosition)
+	case DistanceAppearance:
+		if distance, ok := object.AppearanceDistance(newObject.feature); ok {
+			return distance
+		}
+		return math.MaxFloat64
+	case DistanceMin:
+		dist := newObject.DistanceTo(object)
+		distPredicted := euclideanDistance(newObject.currentCenter, object.predictedNextPosition)
+		return math.Min(dist, distPredicted)
+	default:
+		return newObject.DistanceTo(object)
+	}
+}
+
+// updateTrack updates existing track with matched detection
+func (tracker *SimpleTracker) updateTrack(object *SimpleBlob, newObject *SimpleBlob) error {
+	tracker.observeMove(object, newObject)
+	newObject = tracker.freezeSize(object, newObject)
+	var err error
+	if tracker.scoreWeightedUpdate {
+		err = object.UpdateWeighted(newObject, newObject.confidence)
+	} else {
+		err = object.Update(newObject)
+	}
+	if err != nil {
+		return err
+	}
+	object.lastSeenAt = tracker.frameStamp()
+	object.stampLastPoint(object.lastSeenAt)
+	tracker.recordBBox(object)
+	if len(newObject.feature) > 0 {
+		tracker.pushFeature(object)
+	}
+	if object.confirmed {
+		tracker.emit(EventTrackUpdated, object)
+		return nil
+	}
+	tracker.confirmTrack(object)
+	return nil
+}
+
+// withinGate checks if new object could be matched with existing one which is placed at given distance.
+// Existing object could be nil: then only global threshold is taken into account
+func (tracker *SimpleTracker) withinGate(newObject *SimpleBlob, object *SimpleBlob, distance float64) bool {
+	if tracker.costFunction != nil {
+		return distance < math.MaxFloat64
+	}
+	if tracker.distanceMode == DistanceAppearance {
+		return distance <= tracker.appearanceGate
+	}
+	return distance < newObject.diagonal*0.5 || distance < tracker.gateThreshold(object)
+}
+
+// associate finds the closest existing object for each new object and then resolves conflicts via priority queue
+func (tracker *SimpleTracker) associate(ctx context.Context, newObjects []*SimpleBlob, result *MatchResult, debugStage *DebugStage) error {
+	maxGateThreshold := tracker.minDistThreshold
+	if tracker.index != nil {
+		tracker.index.reset()
+		for _, object := range tracker.storage.tracks {
+			tracker.indexTrack(object)
+			maxGateThreshold = math.Max(maxGateThreshold, tracker.gateThreshold(object))
+		}
+	}
+	priorityQueue := &tracker.buffers.priorityQueue
+	degradedFrom := len(newObjects)
 	for i, newObject := range newObjects {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if tracker.buffers.rejected[i] {
+			continue
+		}
+		if tracker.overBudget() {
+			degradedFrom = i
+			break
+		}
 		minID := uuid.UUID{}
 		minDistance := math.MaxFloat64
-		for objectID, object := range tracker.Objects {
-			dist := newObject.DistanceTo(object)
-			distPredicted := newObject.DistanceTo(object)
-			distVerifided := math.Min(dist, distPredicted)
+		checkObject := func(objectID uuid.UUID, object *SimpleBlob) {
+			distVerifided := tracker.distanceBetween(newObject, object)
+			if debugStage != nil {
+				debugStage.Costs[i][debugStage.columns[objectID]] = distVerifided
+			}
 			if distVerifided < minDistance {
 				minDistance = distVerifided
 				minID = objectID
 			}
 		}
-		distanceBlob := distanceBlob{
-			underlying: newObjects[i],
-			distance:   minDistance,
-			id:         minID,
+		// Objects outside of this radius can't be matched with the new object anyway
+		matchRadius := math.Max(newObject.diagonal*0.5, maxGateThreshold)
+		if tracker.index != nil && tracker.costFunction == nil && tracker.distanceMode != DistanceAppearance && tracker.index.worthQuerying(matchRadius) {
+			tracker.index.query(newObject.currentCenter, matchRadius, checkObject)
+		} else {
+			for _, object := range tracker.storage.tracks {
+				checkObject(object.id, object)
+			}
 		}
-		priorityQueue.Push(&distanceBlob)
+		priorityQueue.Push(acquireDistanceBlob(newObjects[i], i, minID, minDistance))
 	}
 
-	// We need to prevent double update of objects
-	reservedObjects := make(map[uuid.UUID]struct{})
-
+	tracker.stages.enter(StageAssignment)
 	for priorityQueue.Len() > 0 {
 		blobPoped := priorityQueue.Pop()
-		minDistance := blobPoped.distance
-		minID := blobPoped.id
-		underlyingBlob := blobPoped.underlying
-		// Check if object is already reserved
-		// Since we are using priority queue with min-heap then we garantee that we will update existing objects with min distance only once.
-		// For other objects with the same min_id we can create new objects
-		if _, ok := reservedObjects[minID]; ok {
-			// Register it immediately and continue
-			blobsToRegister[underlyingBlob.id] = underlyingBlob
-			continue
-		}
-		// Additional check to filter objects
-		if minDistance < underlyingBlob.diagonal*0.5 || minDistance < tracker.minDistThreshold {
-			if _, ok := tracker.Objects[minID]; ok {
-				err := tracker.Objects[minID].Update(underlyingBlob)
-				if err != nil {
-					return errors.Wrapf(err, "Can't update blob with id %s", minID.String())
-				}
-				// Last but not least:
-				// We need to update ID of new object to match existing one (that is why we have &mut in function definition)
-				underlyingBlob.id = minID
-				reservedObjects[minID] = struct{}{}
-			} else {
-				panic("should be impossible")
-			}
-		} else {
-			// Otherwise register object as a new one
-			blobsToRegister[underlyingBlob.id] = underlyingBlob
+		candidate := *blobPoped
+		releaseDistanceBlob(blobPoped)
+		err := tracker.assignCandidate(&candidate, result, debugStage)
+		if err != nil {
+			return err
 		}
 	}
+	return tracker.assignGreedy(newObjects, degradedFrom, result)
+}
 
-	for blobID := range blobsToRegister {
-		tracker.Objects[blobID] = blobsToRegister[blobID]
-	}
+// assignCandidate updates the closest existing object with the new one or registers the new one as a separate object.
+// Candidates must be passed in ascending order of distance
+func (tracker *SimpleTracker) assignCandidate(candidate *distanceBlob, result *MatchResult, debugStage *DebugStage) error {
+	toRegister := tracker.buffers.toRegister
+	// We need to prevent double update of objects
+	reservedObjects := tracker.buffers.reservedObjects
 
-	// Clean up existing data
-	for objectID := range tracker.Objects {
-		tracker.Objects[objectID].IncNoMatch()
-		// Remove object if it was not found for a long time
-		if tracker.Objects[objectID].GetNoMatchTimes() > tracker.maxNoMatch {
-			delete(tracker.Objects, objectID)
+	minDistance := candidate.distance
+	minID := candidate.id
+	underlyingBlob := candidate.underlying
+	detectionIndex := candidate.index
+	// Check if object is already reserved
+	// Since we are using priority queue with min-heap then we garantee that we will update existing objects with min distance only once.
+	// For other objects with the same min_id we can create new objects
+	if _, ok := reservedObjects[minID]; ok {
+		// Register it immediately and continue
+		toRegister[detectionIndex] = true
+		return nil
+	}
+	// Additional check to filter objects
+	object, exists := tracker.storage.get(minID)
+	if tracker.withinGate(underlyingBlob, object, minDistance) {
+		if exists {
+			return tracker.matchTrack(object, underlyingBlob, detectionIndex, minDistance, result, debugStage)
 		}
+		return errors.Wrapf(ErrUnknownTrack, "Can't find blob with id %s", minID.String())
+	}
+	// Otherwise register object as a new one
+	toRegister[detectionIndex] = true
+	return nil
+}
+
+// matchTrack updates existing object with the new one and reserves it for the rest of the frame
+func (tracker *SimpleTracker) matchTrack(object *SimpleBlob, underlyingBlob *SimpleBlob, detectionIndex int, distance float64, result *MatchResult, debugStage *DebugStage) error {
+	err := tracker.updateTrack(object, underlyingBlob)
+	if err != nil {
+		return errors.Wrapf(err, "Can't update blob with id %s", object.id.String())
+	}
+	// Last but not least:
+	// We need to update ID of new object to match existing one (that is why we have &mut in function definition)
+	underlyingBlob.id = object.id
+	tracker.buffers.reservedObjects[object.id] = struct{}{}
+	if debugStage != nil {
+		debugStage.Assignment[detectionIndex] = debugStage.columns[object.id]
+	}
+	if result != nil {
+		result.Matched = append(result.Matched, MatchedTrack{TrackID: object.id, DetectionIndex: detectionIndex, Score: distance})
 	}
 	return nil
 }
@@ -0,0 +1,273 @@
+package mot
+
+import "time"
+
+// WithMinDistThreshold sets threshold distance (most of time in pixels). Default is 30.0
+func WithMinDistThreshold(minDistThreshold float64) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.minDistThreshold = minDistThreshold
+	}
+}
+
+// WithMaxNoMatch sets max number of frames when object could not be found again. Default is 75
+func WithMaxNoMatch(maxNoMatch int) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.maxNoMatch = maxNoMatch
+	}
+}
+
+// WithConfidenceDecay sets multiplier which is applied to confidence of unmatched tracks each frame. See SimpleTracker.SetConfidenceDecay
+func WithConfidenceDecay(decay float64) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetConfidenceDecay(decay)
+	}
+}
+
+// WithScoreWeightedUpdate enables weighting of Kalman filter's correction by detection confidence. See SimpleTracker.SetScoreWeightedUpdate
+func WithScoreWeightedUpdate() func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetScoreWeightedUpdate(true)
+	}
+}
+
+// WithMinBoxArea sets min area of detection's bounding box. See SimpleTracker.SetMinBoxArea
+func WithMinBoxArea(minBoxArea float64) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetMinBoxArea(minBoxArea)
+	}
+}
+
+// WithMinBoxSize sets min width and height of detection's bounding box. See SimpleTracker.SetMinBoxSize
+func WithMinBoxSize(minWidth, minHeight float64) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetMinBoxSize(minWidth, minHeight)
+	}
+}
+
+// WithAspectRatioRange sets acceptable range of width/height ratio of detection's bounding box. See SimpleTracker.SetAspectRatioRange
+func WithAspectRatioRange(minAspectRatio, maxAspectRatio float64) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetAspectRatioRange(minAspectRatio, maxAspectRatio)
+	}
+}
+
+// WithNMSThreshold enables non-maximum suppression of incoming detections. See SimpleTracker.SetNMSThreshold
+func WithNMSThreshold(iouThreshold float64) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetNMSThreshold(iouThreshold)
+	}
+}
+
+// WithDistanceMode sets which centers of existing tracks are used during matching. Default is DistanceCurrent
+func WithDistanceMode(mode DistanceMode) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetDistanceMode(mode)
+	}
+}
+
+// WithAdaptiveThreshold scales distance threshold by each track's size and speed. See SimpleTracker.SetAdaptiveThreshold
+func WithAdaptiveThreshold(diagonalScale, speedScale float64) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetAdaptiveThreshold(diagonalScale, speedScale)
+	}
+}
+
+// WithMinConsecutiveMatches sets number of consecutive matches which are needed to confirm new track. See SimpleTracker.SetMinConsecutiveMatches
+func WithMinConsecutiveMatches(minConsecutiveMatches int) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetMinConsecutiveMatches(minConsecutiveMatches)
+	}
+}
+
+// WithOnTrackCreated sets function which is called when new track becomes visible. See SimpleTracker.SetOnTrackCreated
+func WithOnTrackCreated(fn func(track *SimpleBlob)) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetOnTrackCreated(fn)
+	}
+}
+
+// WithOnTrackLost sets function which is called when visible track gets lost. See SimpleTracker.SetOnTrackLost
+func WithOnTrackLost(fn func(track *SimpleBlob)) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetOnTrackLost(fn)
+	}
+}
+
+// WithOnTrackRemoved sets function which is called when visible track is removed. See SimpleTracker.SetOnTrackRemoved
+func WithOnTrackRemoved(fn func(track *SimpleBlob)) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetOnTrackRemoved(fn)
+	}
+}
+
+// WithRemovedHistory enables keeping of up to capacity removed tracks. See SimpleTracker.SetRemovedHistory
+func WithRemovedHistory(capacity int) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetRemovedHistory(capacity)
+	}
+}
+
+// WithResurrectionWindow enables identity resurrection for removed tracks. See SimpleTracker.SetResurrectionWindow
+func WithResurrectionWindow(frames int) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetResurrectionWindow(frames)
+	}
+}
+
+// WithBorderExit enables immediate termination of tracks which are leaving the frame. See SimpleTracker.SetBorderExit
+func WithBorderExit(bounds Rectangle) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetBorderExit(bounds)
+	}
+}
+
+// WithClampToFrame enables clamping of detections and predicted bounding boxes to the frame. See SimpleTracker.SetClampToFrame
+func WithClampToFrame(frameWidth, frameHeight float64) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetClampToFrame(frameWidth, frameHeight)
+	}
+}
+
+// WithHooks registers hooks which are called around MatchObjects and its stages. See SimpleTracker.AddHooks
+func WithHooks(hooks FrameHooks) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.AddHooks(hooks)
+	}
+}
+
+// WithDisplayIDs enables compact display identifiers of tracks. See SimpleTracker.SetDisplayIDs
+func WithDisplayIDs(policy DisplayIDPolicy) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetDisplayIDs(policy)
+	}
+}
+
+// WithOcclusionFreeze enables occlusion-freeze mode. See SimpleTracker.SetOcclusionFreeze
+func WithOcclusionFreeze(minAreaRatio float64) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetOcclusionFreeze(minAreaRatio)
+	}
+}
+
+// WithFeatureBudget sets max number of appearance features in gallery of each track. See SimpleTracker.SetFeatureBudget
+func WithFeatureBudget(budget int) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetFeatureBudget(budget)
+	}
+}
+
+// WithAppearanceGate sets max cosine distance between appearance features of matched pairs. See SimpleTracker.SetAppearanceGate
+func WithAppearanceGate(maxCosineDistance float64) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetAppearanceGate(maxCosineDistance)
+	}
+}
+
+// WithAppearanceOnly switches tracker to appearance-only association. See SimpleTracker.SetAppearanceOnly
+func WithAppearanceOnly(maxCosineDistance float64, window time.Duration) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetAppearanceOnly(maxCosineDistance, window)
+	}
+}
+
+// WithCostFunction sets custom association cost. See SimpleTracker.SetCostFunction
+func WithCostFunction(fn CostFunction) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetCostFunction(fn)
+	}
+}
+
+// WithFeatureNormalization sets normalization of detections appearance features. See SimpleTracker.SetFeatureNormalization
+func WithFeatureNormalization(normalization FeatureNormalization) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetFeatureNormalization(normalization)
+	}
+}
+
+// WithTinyObjects configures tracker for tiny objects. See SimpleTracker.SetTinyObjects
+func WithTinyObjects(gsd, minObjectSize, maxDistance float64) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetTinyObjects(gsd, minObjectSize, maxDistance)
+	}
+}
+
+// WithLensDistortion sets lens distortion of the camera. See SimpleTracker.SetLensDistortion.
+// It panics if distortion is invalid (e.g. camera model with zero focal length): call SetLensDistortion directly to get the error instead
+func WithLensDistortion(distortion LensDistortion) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		if err := tracker.SetLensDistortion(distortion); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// WithSpeedCalibration enables speed estimation for all tracks. See SimpleTracker.SetSpeedCalibration
+func WithSpeedCalibration(calibration *SpeedCalibration) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetSpeedCalibration(calibration)
+	}
+}
+
+// WithLatencyBudget sets max duration of association per frame. See SimpleTracker.SetLatencyBudget
+func WithLatencyBudget(budget time.Duration) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetLatencyBudget(budget)
+	}
+}
+
+// WithDropCompensation enables detection of dropped frames from timestamps. See SimpleTracker.SetDropCompensation
+func WithDropCompensation(frameInterval time.Duration) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetFrameInterval(frameInterval)
+		tracker.SetDropCompensation(true)
+	}
+}
+
+// WithIDSwitchMonitor enables heuristics which flag probable identity switches. See SimpleTracker.SetIDSwitchMonitor
+func WithIDSwitchMonitor(maxJump, swapDistance float64) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetIDSwitchMonitor(maxJump, swapDistance)
+	}
+}
+
+// WithSpatialIndex sets type of spatial index which is used to find candidate tracks. Default is SpatialIndexGrid
+func WithSpatialIndex(indexType SpatialIndex) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.indexType = indexType
+	}
+}
+
+// WithMaxObjects bounds number of tracks. See SimpleTracker.SetMaxObjects
+func WithMaxObjects(maxObjects int, policy EvictionPolicy) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetMaxObjects(maxObjects, policy)
+	}
+}
+
+// WithTrackArena makes tracker keep tracks history in arena. See SimpleTracker.SetTrackArena
+func WithTrackArena(chunkTracks int, maxTrackLen int) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetTrackArena(chunkTracks, maxTrackLen)
+	}
+}
+
+// WithTimingCallback sets function which receives durations of MatchObjects stages
+func WithTimingCallback(callback func(timings FrameTimings)) func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetTimingCallback(callback)
+	}
+}
+
+// WithProfilerLabels enables pprof labels for MatchObjects stages
+func WithProfilerLabels() func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetProfilerLabels(true)
+	}
+}
+
+// WithDebug enables capturing of cost matrices during MatchObjects
+func WithDebug() func(*SimpleTracker) {
+	return func(tracker *SimpleTracker) {
+		tracker.SetDebug(true)
+	}
+}
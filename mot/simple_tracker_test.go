@@ -1,8 +1,11 @@
 package mot
 
 import (
+	"context"
 	"encoding/csv"
+	"errors"
 	"fmt"
+	"math"
 	"os"
 	"strings"
 	"testing"
@@ -159,3 +162,376 @@ func TestMatchObjectsSimilar(t *testing.T) {
 		}
 	}
 }
+
+func TestMatchObjectsWithResult(t *testing.T) {
+	tracker := NewNewSimpleTracker(15.0, 5)
+	dt := 1.0 / 25.0
+
+	first := []*SimpleBlob{
+		NewSimpleBlobWithTime(NewRect(10.0, 10.0, 20.0, 20.0), dt),
+		NewSimpleBlobWithTime(NewRect(200.0, 200.0, 20.0, 20.0), dt),
+	}
+	result, err := tracker.MatchObjectsWithResult(first)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result.Created) != 2 || len(result.Matched) != 0 || len(result.Unmatched) != 0 {
+		t.Errorf("incorrect first frame result: %d created, %d matched, %d unmatched, expected: 2, 0, 0", len(result.Created), len(result.Matched), len(result.Unmatched))
+		return
+	}
+	firstID := first[0].GetID()
+	secondID := first[1].GetID()
+
+	second := []*SimpleBlob{
+		NewSimpleBlobWithTime(NewRect(12.0, 11.0, 20.0, 20.0), dt),
+		NewSimpleBlobWithTime(NewRect(500.0, 500.0, 20.0, 20.0), dt),
+	}
+	result, err = tracker.MatchObjectsWithResult(second)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result.Matched) != 1 {
+		t.Errorf("incorrect number of matched tracks: %d, expected: %d", len(result.Matched), 1)
+		return
+	}
+	if result.Matched[0].TrackID != firstID || result.Matched[0].DetectionIndex != 0 {
+		t.Errorf("incorrect match: %s <-> %d, expected: %s <-> %d", result.Matched[0].TrackID, result.Matched[0].DetectionIndex, firstID, 0)
+	}
+	if len(result.Created) != 1 || result.Created[0].DetectionIndex != 1 {
+		t.Errorf("incorrect created tracks: %v, expected single track for detection %d", result.Created, 1)
+	}
+	if len(result.Unmatched) != 1 || result.Unmatched[0] != secondID {
+		t.Errorf("incorrect unmatched tracks: %v, expected: [%s]", result.Unmatched, secondID)
+	}
+}
+
+func TestMatchObjectsDebugInfo(t *testing.T) {
+	tracker := NewNewSimpleTracker(15.0, 5)
+	tracker.SetDebug(true)
+	dt := 1.0 / 25.0
+
+	err := tracker.MatchObjects([]*SimpleBlob{NewSimpleBlobWithTime(NewRect(10.0, 10.0, 20.0, 20.0), dt)})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = tracker.MatchObjects([]*SimpleBlob{
+		NewSimpleBlobWithTime(NewRect(300.0, 300.0, 20.0, 20.0), dt),
+		NewSimpleBlobWithTime(NewRect(13.0, 14.0, 20.0, 20.0), dt),
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	info := tracker.GetDebugInfo()
+	if info == nil || len(info.Stages) != 1 {
+		t.Errorf("debug info should contain exactly one stage")
+		return
+	}
+	stage := info.Stages[0]
+	if len(stage.Costs) != 2 || len(stage.TrackIDs) != 1 {
+		t.Errorf("incorrect cost matrix size: %dx%d, expected: %dx%d", len(stage.Costs), len(stage.TrackIDs), 2, 1)
+		return
+	}
+	if math.Abs(stage.Costs[1][0]-5.0) > eps {
+		t.Errorf("incorrect cost: %v, expected: %v", stage.Costs[1][0], 5.0)
+	}
+	if stage.Assignment[0] != -1 || stage.Assignment[1] != 0 {
+		t.Errorf("incorrect assignment: %v, expected: %v", stage.Assignment, []int{-1, 0})
+	}
+}
+
+func TestSimpleTrackerStats(t *testing.T) {
+	tracker := NewNewSimpleTracker(15.0, 1)
+	dt := 1.0 / 25.0
+	frames := [][]Rectangle{
+		{NewRect(10.0, 10.0, 20.0, 20.0), NewRect(200.0, 200.0, 20.0, 20.0)},
+		{NewRect(11.0, 11.0, 20.0, 20.0)},
+		{NewRect(12.0, 12.0, 20.0, 20.0)},
+	}
+	for _, frame := range frames {
+		blobs := make([]*SimpleBlob, len(frame))
+		for i, bbox := range frame {
+			blobs[i] = NewSimpleBlobWithTime(bbox, dt)
+		}
+		err := tracker.MatchObjects(blobs)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	stats := tracker.Stats()
+	if stats.FramesProcessed != 3 {
+		t.Errorf("incorrect number of frames: %d, expected: %d", stats.FramesProcessed, 3)
+	}
+	if stats.TracksCreated != 2 {
+		t.Errorf("incorrect number of created tracks: %d, expected: %d", stats.TracksCreated, 2)
+	}
+	if stats.TracksRemoved != 1 {
+		t.Errorf("incorrect number of removed tracks: %d, expected: %d", stats.TracksRemoved, 1)
+	}
+	if stats.ActiveTracks != 1 {
+		t.Errorf("incorrect number of active tracks: %d, expected: %d", stats.ActiveTracks, 1)
+	}
+	if math.Abs(stats.AvgMatchesPerFrame-2.0/3.0) > eps {
+		t.Errorf("incorrect average matches per frame: %v, expected: %v", stats.AvgMatchesPerFrame, 2.0/3.0)
+	}
+	// Coasting track is still kept by tracker, but it is not active
+	tracker = NewNewSimpleTracker(15.0, 5)
+	err := tracker.MatchObjects([]*SimpleBlob{NewSimpleBlobWithTime(NewRect(10.0, 10.0, 20.0, 20.0), dt)})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = tracker.MatchObjects([]*SimpleBlob{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	stats = tracker.Stats()
+	if stats.ActiveTracks != 0 || len(tracker.GetTracks()) != 1 {
+		t.Errorf("incorrect number of active / kept tracks: %d / %d, expected: %d / %d", stats.ActiveTracks, len(tracker.GetTracks()), 0, 1)
+	}
+}
+
+func BenchmarkMatchObjects(b *testing.B) {
+	tracker := NewNewSimpleTracker(15.0, 5)
+	dt := 1.0 / 25.0
+	objectsNum := 50
+	frames := make([][]*SimpleBlob, b.N)
+	for frame := range frames {
+		frames[frame] = make([]*SimpleBlob, objectsNum)
+		for i := range frames[frame] {
+			x := float64(i%10)*100.0 + float64(frame%50)
+			y := float64(i/10)*100.0 + float64(frame%50)
+			frames[frame][i] = NewSimpleBlobWithTime(NewRect(x, y, 20.0, 20.0), dt)
+		}
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		err := tracker.MatchObjects(frames[n])
+		if err != nil {
+			b.Error(err)
+			return
+		}
+	}
+}
+
+func TestSimpleTrackerTracksOrder(t *testing.T) {
+	tracker := NewNewSimpleTracker(15.0, 2)
+	dt := 1.0 / 25.0
+	blobs := make([]*SimpleBlob, 20)
+	for i := range blobs {
+		blobs[i] = NewSimpleBlobWithTime(NewRect(float64(i)*100.0, 10.0, 20.0, 20.0), dt)
+	}
+	err := tracker.MatchObjects(blobs)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	// Skip single frame and then keep every second object only
+	err = tracker.MatchObjects([]*SimpleBlob{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	survivors := make([]*SimpleBlob, 0, len(blobs)/2)
+	for i := 0; i < len(blobs); i += 2 {
+		survivors = append(survivors, NewSimpleBlobWithTime(NewRect(float64(i)*100.0, 10.0, 20.0, 20.0), dt))
+	}
+	err = tracker.MatchObjects(survivors)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	tracks := tracker.GetTracks()
+	if len(tracks) != len(survivors) || len(tracker.Objects) != len(survivors) {
+		t.Errorf("incorrect number of tracks: %d (view: %d), expected: %d", len(tracks), len(tracker.Objects), len(survivors))
+		return
+	}
+	for i, track := range tracks {
+		if track.GetID() != blobs[i*2].GetID() {
+			t.Errorf("incorrect track at position %d: %s, expected: %s", i, track.GetID(), blobs[i*2].GetID())
+		}
+	}
+}
+
+func TestMatchSequence(t *testing.T) {
+	tracker := NewNewSimpleTracker(15.0, 5)
+	dt := 1.0 / 25.0
+	frames := make([][]*SimpleBlob, 10)
+	for i := range frames {
+		frames[i] = []*SimpleBlob{
+			NewSimpleBlobWithTime(NewRect(10.0+float64(i), 10.0, 20.0, 20.0), dt),
+			NewSimpleBlobWithTime(NewRect(300.0, 10.0+float64(i), 20.0, 20.0), dt),
+		}
+	}
+	results, err := tracker.MatchSequence(frames)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(results) != len(frames) {
+		t.Errorf("incorrect number of results: %d, expected: %d", len(results), len(frames))
+		return
+	}
+	for i, result := range results[1:] {
+		if len(result.Matched) != 2 || len(result.Created) != 0 {
+			t.Errorf("incorrect result for frame %d: %d matched, %d created, expected: 2, 0", i+1, len(result.Matched), len(result.Created))
+		}
+	}
+}
+
+func TestMatchObjectsCtxCancelled(t *testing.T) {
+	tracker := NewNewSimpleTracker(15.0, 5)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := tracker.MatchObjectsCtx(ctx, []*SimpleBlob{NewSimpleBlob(NewRect(10.0, 10.0, 20.0, 20.0))})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("incorrect error: %v, expected: %v", err, context.Canceled)
+	}
+	if len(tracker.GetTracks()) != 0 {
+		t.Errorf("cancelled call should not register new tracks")
+	}
+}
+
+func TestNewSimpleTrackerOptions(t *testing.T) {
+	tracker := NewSimpleTracker()
+	if tracker.GetMinDistThreshold() != 30.0 || tracker.GetMaxNoMatch() != 75 {
+		t.Errorf("incorrect defaults: %v and %d, expected: %v and %d", tracker.GetMinDistThreshold(), tracker.GetMaxNoMatch(), 30.0, 75)
+	}
+	tracker = NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(5), WithSpatialIndex(SpatialIndexKDTree), WithMaxObjects(10, EvictLongestUnmatched))
+	if tracker.GetMinDistThreshold() != 15.0 || tracker.GetMaxNoMatch() != 5 {
+		t.Errorf("incorrect parameters: %v and %d, expected: %v and %d", tracker.GetMinDistThreshold(), tracker.GetMaxNoMatch(), 15.0, 5)
+	}
+	if tracker.GetSpatialIndex() != SpatialIndexKDTree {
+		t.Errorf("incorrect spatial index: %d, expected: %d", tracker.GetSpatialIndex(), SpatialIndexKDTree)
+	}
+	if maxObjects, policy := tracker.GetMaxObjects(); maxObjects != 10 || policy != EvictLongestUnmatched {
+		t.Errorf("incorrect max objects: %d and %d, expected: %d and %d", maxObjects, policy, 10, EvictLongestUnmatched)
+	}
+	tracker.SetMinDistThreshold(50.0)
+	tracker.SetMaxNoMatch(20)
+	if tracker.GetMinDistThreshold() != 50.0 || tracker.GetMaxNoMatch() != 20 {
+		t.Errorf("incorrect parameters after update: %v and %d, expected: %v and %d", tracker.GetMinDistThreshold(), tracker.GetMaxNoMatch(), 50.0, 20)
+	}
+}
+
+func TestConfidenceDecay(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10), WithConfidenceDecay(0.5))
+	blob := NewSimpleBlob(NewRect(10.0, 10.0, 20.0, 20.0))
+	blob.SetConfidence(0.8)
+	err := tracker.MatchObjects([]*SimpleBlob{blob})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for frame := 0; frame < 2; frame++ {
+		err = tracker.MatchObjects([]*SimpleBlob{})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if math.Abs(blob.GetConfidence()-0.2) > eps {
+		t.Errorf("incorrect decayed confidence: %v, expected: %v", blob.GetConfidence(), 0.2)
+	}
+	matched := NewSimpleBlob(NewRect(11.0, 10.0, 20.0, 20.0))
+	matched.SetConfidence(0.9)
+	err = tracker.MatchObjects([]*SimpleBlob{matched})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if math.Abs(blob.GetConfidence()-0.9) > eps {
+		t.Errorf("incorrect confidence after match: %v, expected: %v", blob.GetConfidence(), 0.9)
+	}
+}
+
+func TestScoreWeightedUpdate(t *testing.T) {
+	shifts := make([]float64, 0, 2)
+	for _, weighted := range []bool{false, true} {
+		tracker := NewSimpleTracker(WithMinDistThreshold(50.0), WithMaxNoMatch(10))
+		tracker.SetScoreWeightedUpdate(weighted)
+		blob := NewSimpleBlob(NewRect(100.0, 100.0, 20.0, 20.0))
+		err := tracker.MatchObjects([]*SimpleBlob{blob})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		jittery := NewSimpleBlob(NewRect(130.0, 100.0, 20.0, 20.0))
+		jittery.SetConfidence(0.1)
+		err = tracker.MatchObjects([]*SimpleBlob{jittery})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		shifts = append(shifts, blob.GetCenter().X-110.0)
+	}
+	if shifts[1] >= shifts[0] {
+		t.Errorf("low-score detection should pull track less: %v, expected less than: %v", shifts[1], shifts[0])
+	}
+}
+
+func TestMinConsecutiveMatches(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10), WithMinConsecutiveMatches(3))
+	noise := NewSimpleBlob(Rectangle{X: 300.0, Y: 300.0, Width: 20.0, Height: 20.0})
+	var stable *SimpleBlob
+	for frame := 0; frame < 3; frame++ {
+		blob := NewSimpleBlob(Rectangle{X: 10.0 + float64(frame), Y: 10.0, Width: 20.0, Height: 20.0})
+		if frame == 0 {
+			stable = blob
+		}
+		detections := []*SimpleBlob{blob}
+		if frame == 0 {
+			detections = append(detections, noise)
+		}
+		err := tracker.MatchObjects(detections)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		confirmed := frame == 2
+		if _, ok := tracker.Objects[stable.GetID()]; ok != confirmed {
+			t.Errorf("incorrect visibility of track on frame %d: %v, expected: %v", frame, ok, confirmed)
+		}
+		if stable.IsConfirmed() != confirmed {
+			t.Errorf("incorrect confirmation of track on frame %d: %v, expected: %v", frame, stable.IsConfirmed(), confirmed)
+		}
+	}
+	if len(tracker.GetTracks()) != 1 || tracker.GetTracks()[0] != stable {
+		t.Errorf("incorrect tracks: %v, expected only confirmed one", tracker.GetTracks())
+	}
+	if _, ok := tracker.storage.get(noise.GetID()); ok {
+		t.Errorf("flickering detection should be removed once it is lost")
+	}
+}
+
+func TestGetActiveTracks(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(10))
+	first := NewSimpleBlob(Rectangle{X: 10.0, Y: 10.0, Width: 20.0, Height: 20.0})
+	second := NewSimpleBlob(Rectangle{X: 200.0, Y: 10.0, Width: 20.0, Height: 20.0})
+	err := tracker.MatchObjects([]*SimpleBlob{first, second})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(tracker.GetActiveTracks()) != 2 {
+		t.Errorf("incorrect number of active tracks after registration: %d, expected: %d", len(tracker.GetActiveTracks()), 2)
+	}
+	err = tracker.MatchObjects([]*SimpleBlob{NewSimpleBlob(Rectangle{X: 11.0, Y: 10.0, Width: 20.0, Height: 20.0})})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	active := tracker.GetActiveTracks()
+	if len(active) != 1 || active[0] != first {
+		t.Errorf("incorrect active tracks: %v, expected only: %v", active, first)
+	}
+	if second.IsActive() {
+		t.Errorf("unmatched track should not be active")
+	}
+}
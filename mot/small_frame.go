@@ -0,0 +1,59 @@
+package mot
+
+import (
+	"context"
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// smallFrameLimit is max number of both existing and new objects for which heap-free association is used
+const smallFrameLimit = 16
+
+// associateSmallFrame does the same as associate, but keeps candidates in fixed-size array on stack
+// and sorts them via insertion sort instead of using spatial index and priority queue.
+// Together with fixed-size Kalman filters it makes steady-state frame free of heap allocations when only a handful of objects is tracked
+func (tracker *SimpleTracker) associateSmallFrame(ctx context.Context, newObjects []*SimpleBlob, result *MatchResult) error {
+	var candidates [smallFrameLimit]distanceBlob
+	n := 0
+	degradedFrom := len(newObjects)
+	for i, newObject := range newObjects {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if tracker.buffers.rejected[i] {
+			continue
+		}
+		if tracker.overBudget() {
+			degradedFrom = i
+			break
+		}
+		minID := uuid.UUID{}
+		minDistance := math.MaxFloat64
+		for _, object := range tracker.storage.tracks {
+			distVerifided := tracker.distanceBetween(newObject, object)
+			if distVerifided < minDistance {
+				minDistance = distVerifided
+				minID = object.id
+			}
+		}
+		candidates[n] = distanceBlob{
+			underlying: newObject,
+			index:      i,
+			id:         minID,
+			distance:   minDistance,
+		}
+		for j := n; j > 0 && candidates[j].distance < candidates[j-1].distance; j-- {
+			candidates[j], candidates[j-1] = candidates[j-1], candidates[j]
+		}
+		n++
+	}
+	tracker.stages.enter(StageAssignment)
+	for i := 0; i < n; i++ {
+		err := tracker.assignCandidate(&candidates[i], result, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return tracker.assignGreedy(newObjects, degradedFrom, result)
+}
@@ -0,0 +1,79 @@
+package mot
+
+import (
+	"testing"
+)
+
+func TestAssociateSmallFrame(t *testing.T) {
+	tracker := NewNewSimpleTracker(15.0, 5)
+	dt := 1.0 / 25.0
+	blobs := make([]*SimpleBlob, 8)
+	for i := range blobs {
+		blobs[i] = NewSimpleBlobWithTime(NewRect(float64(i)*100.0, 10.0, 20.0, 20.0), dt)
+	}
+	err := tracker.MatchObjects(blobs)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for frame := 1; frame < 4; frame++ {
+		// Detections are shuffled, so matching does not depend on their order
+		moved := make([]*SimpleBlob, len(blobs))
+		for i := range moved {
+			moved[len(moved)-1-i] = NewSimpleBlobWithTime(NewRect(float64(i)*100.0+float64(frame), 10.0, 20.0, 20.0), dt)
+		}
+		result, err := tracker.MatchObjectsWithResult(moved)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(result.Matched) != len(blobs) || len(result.Created) != 0 {
+			t.Errorf("incorrect number of matched / created tracks on frame %d: %d / %d, expected: %d / %d", frame, len(result.Matched), len(result.Created), len(blobs), 0)
+		}
+		for _, matched := range result.Matched {
+			expected := blobs[len(moved)-1-matched.DetectionIndex].GetID()
+			if matched.TrackID != expected {
+				t.Errorf("incorrect track for detection %d on frame %d: %v, expected: %v", matched.DetectionIndex, frame, matched.TrackID, expected)
+			}
+		}
+	}
+}
+
+func TestSmallFrameAllocs(t *testing.T) {
+	tracker := NewNewSimpleTracker(15.0, 5)
+	dt := 1.0 / 25.0
+	const warmUp, runs = 200, 100
+	// Detections are created beforehand: only MatchObjects itself is measured
+	frames := make([][]*SimpleBlob, warmUp+runs+1)
+	for f := range frames {
+		frames[f] = make([]*SimpleBlob, 8)
+		for i := range frames[f] {
+			shift := float64(f%20) * 0.5
+			frames[f][i] = NewSimpleBlobWithTime(NewRect(float64(i)*100.0+shift, 10.0, 20.0, 20.0), dt)
+		}
+	}
+	// Let tracks histories reach their max length
+	for f := 0; f < warmUp; f++ {
+		err := tracker.MatchObjects(frames[f])
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	next := warmUp
+	var err error
+	allocs := testing.AllocsPerRun(runs, func() {
+		err = tracker.MatchObjects(frames[next])
+		next++
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(tracker.GetTracks()) != 8 {
+		t.Errorf("incorrect number of tracks: %d, expected: %d", len(tracker.GetTracks()), 8)
+	}
+	if allocs != 0 {
+		t.Errorf("incorrect number of allocations per frame: %v, expected: %v", allocs, 0)
+	}
+}
@@ -0,0 +1,187 @@
+package mot
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// snapshotVersion is version of snapshot format. It is increased on incompatible changes
+const snapshotVersion = 1
+
+// trackerSnapshot is serialized state of SimpleTracker
+type trackerSnapshot struct {
+	Version       int             `json:"version"`
+	Frame         int             `json:"frame"`
+	TracksCreated int             `json:"tracks_created"`
+	TracksRemoved int             `json:"tracks_removed"`
+	MatchesTotal  int             `json:"matches_total"`
+	NextDisplayID int             `json:"next_display_id,omitempty"`
+	Tracks        []trackSnapshot `json:"tracks"`
+}
+
+// trackSnapshot is serialized state of single track
+type trackSnapshot struct {
+	ID                 uuid.UUID    `json:"id"`
+	BBox               Rectangle    `json:"bbox"`
+	Center             Point        `json:"center"`
+	Predicted          Point        `json:"predicted"`
+	Track              []Point      `json:"track"`
+	Stamps             []FrameStamp `json:"stamps"`
+	BBoxes             []Rectangle  `json:"bboxes,omitempty"`
+	MaxTrackLen        int          `json:"max_track_len"`
+	Active             bool         `json:"active"`
+	NoMatchTimes       int          `json:"no_match_times"`
+	Diagonal           float64      `json:"diagonal"`
+	Confidence         float64      `json:"confidence"`
+	ConsecutiveMatches int          `json:"consecutive_matches"`
+	Matches            int          `json:"matches"`
+	Confirmed          bool         `json:"confirmed"`
+	Pinned             bool         `json:"pinned,omitempty"`
+	DisplayID          int          `json:"display_id,omitempty"`
+	Class              string       `json:"class,omitempty"`
+	Feature            []float32    `json:"feature,omitempty"`
+	Gallery            [][]float32  `json:"gallery,omitempty"`
+	CreatedAt          FrameStamp   `json:"created_at"`
+	LastSeenAt         FrameStamp   `json:"last_seen_at"`
+	// Kalman filter: time step, state vector (x, y, vx, vy) and error covariance matrix (row-major)
+	Dt         float64     `json:"dt"`
+	State      [4]float64  `json:"state"`
+	Covariance [16]float64 `json:"covariance"`
+}
+
+// Snapshot serializes tracks (including Kalman filters state) and frame counter, so tracking could be resumed via Restore
+// with the same identifiers. Tracker's configuration, callbacks, removed tracks history and resurrection candidates are not included:
+// restore snapshot into tracker which has been created with the same options
+func (tracker *SimpleTracker) Snapshot() ([]byte, error) {
+	snapshot := trackerSnapshot{
+		Version:       snapshotVersion,
+		Frame:         tracker.counters.framesProcessed,
+		TracksCreated: tracker.counters.tracksCreated,
+		TracksRemoved: tracker.counters.tracksRemoved,
+		MatchesTotal:  tracker.counters.matchesTotal,
+		Tracks:        make([]trackSnapshot, 0, tracker.storage.len()),
+	}
+	for _, object := range tracker.storage.tracks {
+		snapshot.Tracks = append(snapshot.Tracks, newTrackSnapshot(object))
+	}
+	if tracker.displayIDs != nil {
+		tracker.displayIDs.mu.Lock()
+		snapshot.NextDisplayID = tracker.displayIDs.next
+		tracker.displayIDs.mu.Unlock()
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, errors.Wrap(err, "Can't encode snapshot")
+	}
+	return data, nil
+}
+
+// Restore replaces tracks and frame counter with the ones from snapshot made by Snapshot.
+// Replaced tracks are dropped silently: neither callbacks nor events are fired for them
+func (tracker *SimpleTracker) Restore(data []byte) error {
+	snapshot := trackerSnapshot{}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return errors.Wrapf(withSentinel(ErrInvalidSnapshot, err), "Can't decode snapshot")
+	}
+	if snapshot.Version != snapshotVersion {
+		return errors.Wrapf(ErrInvalidSnapshot, "Unsupported snapshot version %d (expected %d)", snapshot.Version, snapshotVersion)
+	}
+	blobs := make([]*SimpleBlob, 0, len(snapshot.Tracks))
+	seen := make(map[uuid.UUID]struct{}, len(snapshot.Tracks))
+	for i := range snapshot.Tracks {
+		trackData := &snapshot.Tracks[i]
+		if _, ok := seen[trackData.ID]; ok {
+			return errors.Wrapf(ErrInvalidSnapshot, "Duplicate track %s", trackData.ID.String())
+		}
+		seen[trackData.ID] = struct{}{}
+		if len(trackData.Stamps) != len(trackData.Track) {
+			return errors.Wrapf(ErrInvalidSnapshot, "Track %s has %d points but %d stamps", trackData.ID.String(), len(trackData.Track), len(trackData.Stamps))
+		}
+		blobs = append(blobs, trackData.blob())
+	}
+	for _, object := range tracker.storage.tracks {
+		tracker.detachFromArena(object)
+	}
+	tracker.storage = newTrackStorage()
+	tracker.Objects = make(map[uuid.UUID]*SimpleBlob, len(blobs))
+	for _, blob := range blobs {
+		tracker.addTrack(blob)
+	}
+	if tracker.displayIDs != nil {
+		used := make([]int, 0, len(blobs))
+		for _, blob := range blobs {
+			if blob.displayID != 0 {
+				used = append(used, blob.displayID)
+			}
+		}
+		tracker.displayIDs.reset(snapshot.NextDisplayID, used)
+	}
+	tracker.counters.framesProcessed = snapshot.Frame
+	tracker.counters.tracksCreated = snapshot.TracksCreated
+	tracker.counters.tracksRemoved = snapshot.TracksRemoved
+	tracker.counters.matchesTotal = snapshot.MatchesTotal
+	return nil
+}
+
+// newTrackSnapshot captures state of the given track
+func newTrackSnapshot(blob *SimpleBlob) trackSnapshot {
+	trackData := trackSnapshot{
+		ID:                 blob.id,
+		BBox:               blob.currentBBox,
+		Center:             blob.currentCenter,
+		Predicted:          blob.predictedNextPosition,
+		Track:              append([]Point{}, blob.track...),
+		Stamps:             append([]FrameStamp{}, blob.trackStamps...),
+		BBoxes:             append([]Rectangle{}, blob.bboxHistory...),
+		MaxTrackLen:        blob.maxTrackLen,
+		Active:             blob.active,
+		NoMatchTimes:       blob.noMatchTimes,
+		Diagonal:           blob.diagonal,
+		Confidence:         blob.confidence,
+		ConsecutiveMatches: blob.consecutiveMatches,
+		Matches:            blob.matches,
+		Confirmed:          blob.confirmed,
+		Pinned:             blob.pinned,
+		DisplayID:          blob.displayID,
+		Class:              blob.class,
+		Feature:            blob.feature,
+		Gallery:            blob.gallery,
+		CreatedAt:          blob.createdAt,
+		LastSeenAt:         blob.lastSeenAt,
+		Dt:                 blob.tracker.dt,
+		State:              blob.tracker.x,
+		Covariance:         blob.tracker.p,
+	}
+	return trackData
+}
+
+// blob creates track from its captured state
+func (trackData *trackSnapshot) blob() *SimpleBlob {
+	blob := NewSimpleBlobWithCenterTime(trackData.Center, trackData.BBox, trackData.Dt)
+	blob.id = trackData.ID
+	blob.predictedNextPosition = trackData.Predicted
+	blob.track = append(make([]Point, 0, trackData.MaxTrackLen), trackData.Track...)
+	blob.trackStamps = append([]FrameStamp{}, trackData.Stamps...)
+	if len(trackData.BBoxes) > 0 {
+		blob.bboxHistory = append([]Rectangle{}, trackData.BBoxes...)
+	}
+	blob.maxTrackLen = trackData.MaxTrackLen
+	blob.active = trackData.Active
+	blob.noMatchTimes = trackData.NoMatchTimes
+	blob.diagonal = trackData.Diagonal
+	blob.confidence = trackData.Confidence
+	blob.consecutiveMatches = trackData.ConsecutiveMatches
+	blob.matches = trackData.Matches
+	blob.confirmed = trackData.Confirmed
+	blob.pinned = trackData.Pinned
+	blob.displayID = trackData.DisplayID
+	blob.class = trackData.Class
+	blob.feature = trackData.Feature
+	blob.gallery = trackData.Gallery
+	blob.createdAt = trackData.CreatedAt
+	blob.lastSeenAt = trackData.LastSeenAt
+	blob.tracker.setState(trackData.State, trackData.Covariance)
+	return blob
+}
@@ -0,0 +1,75 @@
+package mot
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	options := []func(*SimpleTracker){WithMinDistThreshold(15.0), WithMaxNoMatch(10)}
+	tracker := NewSimpleTracker(options...)
+	for i := 0; i < 5; i++ {
+		err := tracker.MatchObjects([]*SimpleBlob{
+			NewSimpleBlob(Rectangle{X: 10.0 + 3.0*float64(i), Y: 10.0, Width: 20.0, Height: 20.0}),
+			NewSimpleBlob(Rectangle{X: 200.0, Y: 100.0 - 2.0*float64(i), Width: 30.0, Height: 30.0}),
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	data, err := tracker.Snapshot()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	restored := NewSimpleTracker(options...)
+	err = restored.Restore(data)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if restored.GetFrame() != tracker.GetFrame() {
+		t.Errorf("incorrect restored frame: %d, expected: %d", restored.GetFrame(), tracker.GetFrame())
+	}
+	// Both trackers should evolve identically, including velocities of Kalman filters
+	for i := 5; i < 8; i++ {
+		for _, trk := range []*SimpleTracker{tracker, restored} {
+			err := trk.MatchObjects([]*SimpleBlob{
+				NewSimpleBlob(Rectangle{X: 10.0 + 3.0*float64(i), Y: 10.0, Width: 20.0, Height: 20.0}),
+				NewSimpleBlob(Rectangle{X: 200.0, Y: 100.0 - 2.0*float64(i), Width: 30.0, Height: 30.0}),
+			})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}
+	original := tracker.GetTracks()
+	restoredTracks := restored.GetTracks()
+	if len(original) != len(restoredTracks) {
+		t.Errorf("incorrect number of restored tracks: %d, expected: %d", len(restoredTracks), len(original))
+		return
+	}
+	for i := range original {
+		if original[i].GetID() != restoredTracks[i].GetID() {
+			t.Errorf("incorrect restored track id: %s, expected: %s", restoredTracks[i].GetID(), original[i].GetID())
+		}
+		if len(original[i].GetTrack()) != len(restoredTracks[i].GetTrack()) {
+			t.Errorf("incorrect restored track length: %d, expected: %d", len(restoredTracks[i].GetTrack()), len(original[i].GetTrack()))
+		}
+		if euclideanDistance(original[i].GetCenter(), restoredTracks[i].GetCenter()) > 1e-9 {
+			t.Errorf("incorrect restored track center: %v, expected: %v", restoredTracks[i].GetCenter(), original[i].GetCenter())
+		}
+	}
+}
+
+func TestRestoreInvalidSnapshot(t *testing.T) {
+	tracker := NewSimpleTracker()
+	for _, data := range []string{"not json", `{"version":100}`} {
+		err := tracker.Restore([]byte(data))
+		if !errors.Is(err, ErrInvalidSnapshot) {
+			t.Errorf("incorrect error for snapshot %q: %v, expected: %v", data, err, ErrInvalidSnapshot)
+		}
+	}
+}
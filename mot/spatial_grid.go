@@ -0,0 +1,108 @@
+package mot
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// SpatialIndex defines how SimpleTracker looks for candidate tracks near each detection
+type SpatialIndex uint16
+
+const (
+	// SpatialIndexGrid uses uniform grid with cell size equal to the distance threshold
+	SpatialIndexGrid = SpatialIndex(iota)
+	// SpatialIndexKDTree uses 2-d tree over tracks centers. It does not depend on the distance threshold,
+	// so it is better choice when number of tracks is large and objects sizes vary a lot
+	SpatialIndexKDTree
+	// SpatialIndexNone disables spatial index: each detection is compared with each track
+	SpatialIndexNone
+)
+
+// spatialIndex is the common interface for structures which are used to find candidate tracks
+type spatialIndex interface {
+	reset()
+	insert(id uuid.UUID, object *SimpleBlob, pt Point)
+	worthQuerying(radius float64) bool
+	query(pt Point, radius float64, fn func(id uuid.UUID, object *SimpleBlob))
+}
+
+// newSpatialIndex creates spatial index of given type. Returns nil if index can't be used
+func newSpatialIndex(indexType SpatialIndex, minDistThreshold float64) spatialIndex {
+	switch indexType {
+	case SpatialIndexGrid:
+		if minDistThreshold <= 0 {
+			return nil
+		}
+		return newSpatialGrid(minDistThreshold)
+	case SpatialIndexKDTree:
+		return newKDTree()
+	default:
+		return nil
+	}
+}
+
+type gridCell struct {
+	x int
+	y int
+}
+
+type gridEntry struct {
+	id     uuid.UUID
+	object *SimpleBlob
+}
+
+// spatialGrid is uniform grid over tracks centers. It is used to avoid comparing each detection with each track
+type spatialGrid struct {
+	cellSize float64
+	size     int
+	cells    map[gridCell][]gridEntry
+}
+
+func newSpatialGrid(cellSize float64) *spatialGrid {
+	return &spatialGrid{
+		cellSize: cellSize,
+		cells:    make(map[gridCell][]gridEntry),
+	}
+}
+
+func (grid *spatialGrid) cellOf(pt Point) gridCell {
+	return gridCell{
+		x: int(math.Floor(pt.X / grid.cellSize)),
+		y: int(math.Floor(pt.Y / grid.cellSize)),
+	}
+}
+
+// reset removes all entries from the grid
+func (grid *spatialGrid) reset() {
+	for cell := range grid.cells {
+		delete(grid.cells, cell)
+	}
+	grid.size = 0
+}
+
+// insert puts track into the cell which contains given point
+func (grid *spatialGrid) insert(id uuid.UUID, object *SimpleBlob, pt Point) {
+	cell := grid.cellOf(pt)
+	grid.cells[cell] = append(grid.cells[cell], gridEntry{id: id, object: object})
+	grid.size++
+}
+
+// worthQuerying checks if scanning cells within given radius is cheaper than scanning all tracks
+func (grid *spatialGrid) worthQuerying(radius float64) bool {
+	span := 2*int(math.Ceil(radius/grid.cellSize)) + 1
+	return span*span < grid.size
+}
+
+// query calls fn for each track in cells which intersect square with given center and half-side equal to radius
+func (grid *spatialGrid) query(pt Point, radius float64, fn func(id uuid.UUID, object *SimpleBlob)) {
+	minCell := grid.cellOf(Point{X: pt.X - radius, Y: pt.Y - radius})
+	maxCell := grid.cellOf(Point{X: pt.X + radius, Y: pt.Y + radius})
+	for x := minCell.x; x <= maxCell.x; x++ {
+		for y := minCell.y; y <= maxCell.y; y++ {
+			for _, entry := range grid.cells[gridCell{x: x, y: y}] {
+				fn(entry.id, entry.object)
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package mot
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestSpatialGridQuery(t *testing.T) {
+	grid := newSpatialGrid(10.0)
+	near := uuid.New()
+	far := uuid.New()
+	grid.insert(near, nil, Point{X: 12, Y: 15})
+	grid.insert(far, nil, Point{X: 120, Y: 150})
+	found := make(map[uuid.UUID]struct{})
+	grid.query(Point{X: 5, Y: 5}, 10.0, func(id uuid.UUID, _ *SimpleBlob) {
+		found[id] = struct{}{}
+	})
+	if _, ok := found[near]; !ok {
+		t.Errorf("near entry has not been found")
+	}
+	if _, ok := found[far]; ok {
+		t.Errorf("far entry should not be found")
+	}
+	grid.reset()
+	if grid.size != 0 || len(grid.cells) != 0 {
+		t.Errorf("grid should be empty after reset")
+	}
+}
+
+func TestKDTreeQuery(t *testing.T) {
+	tree := newKDTree()
+	ids := make([]uuid.UUID, 100)
+	for i := range ids {
+		ids[i] = uuid.New()
+		tree.insert(ids[i], nil, Point{X: float64(i%10) * 10.0, Y: float64(i/10) * 10.0})
+	}
+	found := make(map[uuid.UUID]struct{})
+	tree.query(Point{X: 42, Y: 42}, 5.0, func(id uuid.UUID, _ *SimpleBlob) {
+		found[id] = struct{}{}
+	})
+	// Only (40, 40) lies within the query square
+	if len(found) != 1 {
+		t.Errorf("incorrect number of found entries: %d, expected: %d", len(found), 1)
+		return
+	}
+	if _, ok := found[ids[44]]; !ok {
+		t.Errorf("entry (40, 40) has not been found")
+	}
+}
+
+func TestMatchObjectsCrowded(t *testing.T) {
+	indexTypes := []SpatialIndex{SpatialIndexGrid, SpatialIndexKDTree, SpatialIndexNone}
+	for _, indexType := range indexTypes {
+		tracker := NewNewSimpleTracker(15.0, 5)
+		tracker.SetSpatialIndex(indexType)
+		dt := 1.0 / 25.0
+		objectsNum := 100
+		for frame := 0; frame < 10; frame++ {
+			blobs := make([]*SimpleBlob, objectsNum)
+			for i := range blobs {
+				x := float64(i%10)*100.0 + float64(frame)
+				y := float64(i/10)*100.0 + float64(frame)
+				blobs[i] = NewSimpleBlobWithTime(NewRect(x, y, 20.0, 20.0), dt)
+			}
+			err := tracker.MatchObjects(blobs)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		if len(tracker.Objects) != objectsNum {
+			t.Errorf("incorrect number of objects for index %d: %d, expected: %d", indexType, len(tracker.Objects), objectsNum)
+		}
+	}
+}
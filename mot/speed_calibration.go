@@ -0,0 +1,106 @@
+package mot
+
+import "time"
+
+// defaultSpeedWindow is default number of the latest track points which speed is estimated over
+const defaultSpeedWindow = 5
+
+// SpeedCalibration maps image points to the ground plane (in meters) to estimate real-world speed of tracks.
+// Note: track points are centers of bounding boxes, so they are assumed to lie on the ground plane
+type SpeedCalibration struct {
+	toWorld Homography
+	window  int
+}
+
+// NewSpeedCalibration creates calibration from at least 4 reference points of the image and their known coordinates on the ground plane (in meters),
+// e.g. corners of road markings. See FindHomography for errors
+func NewSpeedCalibration(imagePoints, worldPoints []Point) (*SpeedCalibration, error) {
+	toWorld, err := FindHomography(imagePoints, worldPoints)
+	if err != nil {
+		return nil, err
+	}
+	return &SpeedCalibration{
+		toWorld: toWorld,
+		window:  defaultSpeedWindow,
+	}, nil
+}
+
+// GetHomography returns transformation from image to ground plane
+func (calibration *SpeedCalibration) GetHomography() Homography {
+	return calibration.toWorld
+}
+
+// ToWorld maps image point to the ground plane (in meters)
+func (calibration *SpeedCalibration) ToWorld(pt Point) Point {
+	return calibration.toWorld.Apply(pt)
+}
+
+// SetWindow sets number of the latest track points (at least 2) which speed is estimated over. Larger window smooths detection jitter,
+// but reacts to acceleration slower. Default is 5
+func (calibration *SpeedCalibration) SetWindow(points int) {
+	if points < 2 {
+		points = 2
+	}
+	calibration.window = points
+}
+
+// GetWindow returns number of the latest track points which speed is estimated over
+func (calibration *SpeedCalibration) GetWindow() int {
+	return calibration.window
+}
+
+// Speed returns speed of blob in km/h. Elapsed time is evaluated from frame numbers if frameInterval is positive
+// or from timestamps of track points otherwise. Returns false if there are not enough track points or elapsed time is unknown.
+// Track points are mapped as image points, so use SimpleTracker.GetSpeed if tracker corrects lens distortion
+func (calibration *SpeedCalibration) Speed(blob *SimpleBlob, frameInterval time.Duration) (float64, bool) {
+	return calibration.speed(blob, frameInterval, func(pt Point) Point { return pt })
+}
+
+// speed implements Speed. Track points are mapped to the image via toImage before they are mapped to the ground plane
+func (calibration *SpeedCalibration) speed(blob *SimpleBlob, frameInterval time.Duration, toImage func(Point) Point) (float64, bool) {
+	n := len(blob.track)
+	if n < 2 || len(blob.trackStamps) != n {
+		return 0, false
+	}
+	first := n - calibration.window
+	if first < 0 {
+		first = 0
+	}
+	var elapsed time.Duration
+	if frameInterval > 0 {
+		elapsed = time.Duration(blob.trackStamps[n-1].Frame-blob.trackStamps[first].Frame) * frameInterval
+	} else if !blob.trackStamps[first].Timestamp.IsZero() {
+		elapsed = blob.trackStamps[n-1].Timestamp.Sub(blob.trackStamps[first].Timestamp)
+	}
+	if elapsed <= 0 {
+		return 0, false
+	}
+	from := calibration.ToWorld(toImage(blob.track[first]))
+	to := calibration.ToWorld(toImage(blob.track[n-1]))
+	meters := euclideanDistance(from, to)
+	if !isFinite(meters) {
+		return 0, false
+	}
+	return meters / elapsed.Seconds() * 3.6, true
+}
+
+// SetSpeedCalibration enables speed estimation for all tracks (see GetSpeed). Nil disables it
+func (tracker *SimpleTracker) SetSpeedCalibration(calibration *SpeedCalibration) {
+	tracker.speedCalibration = calibration
+}
+
+// GetSpeedCalibration returns calibration which is used for speed estimation
+func (tracker *SimpleTracker) GetSpeedCalibration() *SpeedCalibration {
+	return tracker.speedCalibration
+}
+
+// GetSpeed returns speed of track in km/h. Elapsed time is evaluated via frame interval if it is set (see SetFrameInterval)
+// or via timestamps of frames otherwise (provide them via MatchObjectsAt for offline processing).
+// Calibration points are image points: if lens distortion is corrected (see SetLensDistortion), rectified track points are mapped back to the image first.
+// Returns false if calibration is not set or speed can't be estimated yet
+func (tracker *SimpleTracker) GetSpeed(blob *SimpleBlob) (float64, bool) {
+	if tracker.speedCalibration == nil {
+		return 0, false
+	}
+	return tracker.speedCalibration.speed(blob, tracker.frameInterval, tracker.distortPoint)
+}
@@ -0,0 +1,73 @@
+package mot
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSpeedCalibration(t *testing.T) {
+	// 10 pixels per meter
+	imagePoints := []Point{{X: 0.0, Y: 0.0}, {X: 500.0, Y: 0.0}, {X: 500.0, Y: 300.0}, {X: 0.0, Y: 300.0}}
+	worldPoints := []Point{{X: 0.0, Y: 0.0}, {X: 50.0, Y: 0.0}, {X: 50.0, Y: 30.0}, {X: 0.0, Y: 30.0}}
+	calibration, err := NewSpeedCalibration(imagePoints, worldPoints)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	tracker := NewSimpleTracker(WithSpeedCalibration(calibration))
+	tracker.SetFrameInterval(40 * time.Millisecond)
+	for i := 0; i < 10; i++ {
+		// 5 pixels per frame at 25 FPS is 12.5 m/s
+		err := tracker.MatchObjects([]*SimpleBlob{NewSimpleBlob(Rectangle{X: 50.0 + 5.0*float64(i), Y: 100.0, Width: 20.0, Height: 20.0})})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	speed, ok := tracker.GetSpeed(tracker.GetTracks()[0])
+	if !ok {
+		t.Errorf("speed should be estimated")
+		return
+	}
+	if math.Abs(speed-45.0) > 2.0 {
+		t.Errorf("incorrect speed: %v, expected: %v", speed, 45.0)
+	}
+	if _, ok := tracker.GetSpeed(NewSimpleBlob(Rectangle{X: 0.0, Y: 0.0, Width: 10.0, Height: 10.0})); ok {
+		t.Errorf("speed of blob with single point should not be estimated")
+	}
+}
+
+func TestSpeedCalibrationLensDistortion(t *testing.T) {
+	// 10 pixels per meter in the image
+	imagePoints := []Point{{X: 0.0, Y: 0.0}, {X: 500.0, Y: 0.0}, {X: 500.0, Y: 300.0}, {X: 0.0, Y: 300.0}}
+	worldPoints := []Point{{X: 0.0, Y: 0.0}, {X: 50.0, Y: 0.0}, {X: 50.0, Y: 30.0}, {X: 0.0, Y: 30.0}}
+	calibration, err := NewSpeedCalibration(imagePoints, worldPoints)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	// Rectified coordinates are twice smaller than image ones
+	scale := LensDistortionFuncs{
+		UndistortFunc: func(pt Point) Point { return Point{X: pt.X / 2.0, Y: pt.Y / 2.0} },
+		DistortFunc:   func(pt Point) Point { return Point{X: pt.X * 2.0, Y: pt.Y * 2.0} },
+	}
+	tracker := NewSimpleTracker(WithSpeedCalibration(calibration), WithLensDistortion(scale))
+	tracker.SetFrameInterval(40 * time.Millisecond)
+	for i := 0; i < 10; i++ {
+		// 5 image pixels per frame at 25 FPS is 12.5 m/s
+		err := tracker.MatchObjects([]*SimpleBlob{NewSimpleBlob(Rectangle{X: 50.0 + 5.0*float64(i), Y: 100.0, Width: 20.0, Height: 20.0})})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	speed, ok := tracker.GetSpeed(tracker.GetTracks()[0])
+	if !ok {
+		t.Errorf("speed should be estimated")
+		return
+	}
+	if math.Abs(speed-45.0) > 2.0 {
+		t.Errorf("incorrect speed: %v, expected: %v", speed, 45.0)
+	}
+}
@@ -0,0 +1,85 @@
+package mot
+
+import (
+	"math"
+	"time"
+)
+
+// SetFrameInterval sets expected time between frames. It is used by Resume to convert stall duration into number of frames.
+// Zero means that interval is estimated from timestamps of the last two frames
+func (tracker *SimpleTracker) SetFrameInterval(interval time.Duration) {
+	tracker.frameInterval = interval
+}
+
+// GetFrameInterval returns expected time between frames (zero if it is estimated from timestamps)
+func (tracker *SimpleTracker) GetFrameInterval() time.Duration {
+	return tracker.frameInterval
+}
+
+// Suspend pauses tracking (e.g. when video stream stalls). MatchObjects returns ErrSuspended until Resume is called
+func (tracker *SimpleTracker) Suspend() {
+	tracker.suspended = true
+}
+
+// IsSuspended returns whether tracking is paused
+func (tracker *SimpleTracker) IsSuspended() bool {
+	return tracker.suspended
+}
+
+// Resume continues tracking after Suspend. All tracks are moved forward with their velocities by number of frames which fit into gap
+// (uncertainty of Kalman filters grows accordingly), so tracks are expected near their actual positions on the next frame.
+// Number of advanced frames is limited by max no match.
+// No match counters stay untouched, so tracks are not declared lost because of the stall
+func (tracker *SimpleTracker) Resume(gap time.Duration) {
+	tracker.suspended = false
+	interval := tracker.frameInterval
+	if interval <= 0 && !tracker.prevFrameTime.IsZero() {
+		interval = tracker.frameTime.Sub(tracker.prevFrameTime)
+	}
+	if gap <= 0 || interval <= 0 {
+		return
+	}
+	steps := int(math.Round(float64(gap) / float64(interval)))
+	if steps > tracker.maxNoMatch {
+		steps = tracker.maxNoMatch
+	}
+	for _, object := range tracker.storage.tracks {
+		object.advance(steps)
+	}
+}
+
+// advance moves blob forward by given number of frames with its current velocity. Covariance of Kalman filter
+// grows as after the same number of prediction steps, but control input is not applied, so the state does not drift
+func (blob *SimpleBlob) advance(steps int) {
+	if steps <= 0 {
+		return
+	}
+	vector := blob.tracker.x
+	dt := blob.tracker.dt
+	for i := 0; i < steps; i++ {
+		blob.tracker.predict()
+	}
+	shiftX := vector[2] * dt * float64(steps)
+	shiftY := vector[3] * dt * float64(steps)
+	state := [4]float64{vector[0] + shiftX, vector[1] + shiftY, vector[2], vector[3]}
+	blob.tracker.setState(state, blob.tracker.p)
+	blob.currentCenter.X += shiftX
+	blob.currentCenter.Y += shiftY
+	blob.currentBBox.X += shiftX
+	blob.currentBBox.Y += shiftY
+	blob.predictedNextPosition = Point{X: state[0], Y: state[1]}
+}
+
+// Suspend pauses tracking in all tiles
+func (tracker *TiledTracker) Suspend() {
+	for _, tile := range tracker.tiles {
+		tile.Suspend()
+	}
+}
+
+// Resume continues tracking in all tiles. See SimpleTracker.Resume
+func (tracker *TiledTracker) Resume(gap time.Duration) {
+	for _, tile := range tracker.tiles {
+		tile.Resume(gap)
+	}
+}
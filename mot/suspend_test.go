@@ -0,0 +1,47 @@
+package mot
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSuspendResume(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	interval := 40 * time.Millisecond
+	blobAt := func(frame int) *SimpleBlob {
+		return NewSimpleBlob(Rectangle{X: 10.0 + 5.0*float64(frame), Y: 10.0, Width: 20.0, Height: 20.0})
+	}
+	for _, compensate := range []bool{true, false} {
+		tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(25))
+		for i := 0; i < 20; i++ {
+			err := tracker.MatchObjectsAt(start.Add(time.Duration(i)*interval), []*SimpleBlob{blobAt(i)})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		tracker.Suspend()
+		err := tracker.MatchObjects([]*SimpleBlob{blobAt(20)})
+		if !errors.Is(err, ErrSuspended) {
+			t.Errorf("incorrect error for suspended tracker: %v, expected: %v", err, ErrSuspended)
+		}
+		// Stream stalls for 10 frames
+		gap := 10 * interval
+		if !compensate {
+			gap = 0
+		}
+		tracker.Resume(gap)
+		result, err := tracker.MatchObjectsWithResultAt(start.Add(30*interval), []*SimpleBlob{blobAt(30)})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if compensate && len(result.Matched) != 1 {
+			t.Errorf("track should be matched after compensated stall, got: %v", result)
+		}
+		if !compensate && len(result.Matched) != 0 {
+			t.Errorf("track should not be matched after stall without compensation, got: %v", result)
+		}
+	}
+}
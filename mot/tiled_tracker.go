@@ -0,0 +1,296 @@
+package mot
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// TiledTracker partitions the frame into tiles and runs separate SimpleTracker for each tile in parallel.
+// Tracks crossing tile boundaries are handed off to the tile where their new detection has been found
+type TiledTracker struct {
+	// Size of single tile
+	tileWidth  float64
+	tileHeight float64
+	// Number of tiles along X and Y axes
+	cols int
+	rows int
+	// Sub-trackers (row-major order)
+	tiles []*SimpleTracker
+}
+
+// NewTiledTracker creates new instance of TiledTracker
+//
+// frameWidth, frameHeight - size of the whole frame
+// cols, rows - number of tiles along X and Y axes
+// minDistThreshold, maxNoMatch - parameters for each tile's SimpleTracker
+func NewTiledTracker(frameWidth, frameHeight float64, cols, rows int, minDistThreshold float64, maxNoMatch int) *TiledTracker {
+	if cols < 1 {
+		cols = 1
+	}
+	if rows < 1 {
+		rows = 1
+	}
+	tiles := make([]*SimpleTracker, cols*rows)
+	for i := range tiles {
+		tiles[i] = NewSimpleTracker(WithMinDistThreshold(minDistThreshold), WithMaxNoMatch(maxNoMatch))
+	}
+	return &TiledTracker{
+		tileWidth:  frameWidth / float64(cols),
+		tileHeight: frameHeight / float64(rows),
+		cols:       cols,
+		rows:       rows,
+		tiles:      tiles,
+	}
+}
+
+// GetTracks returns tracks of all tiles
+func (tracker *TiledTracker) GetTracks() []*SimpleBlob {
+	tracks := make([]*SimpleBlob, 0)
+	for _, tile := range tracker.tiles {
+		tracks = append(tracks, tile.GetTracks()...)
+	}
+	return tracks
+}
+
+// GetTrack returns track by its identifier
+func (tracker *TiledTracker) GetTrack(id uuid.UUID) (*SimpleBlob, bool) {
+	for _, tile := range tracker.tiles {
+		if blob, ok := tile.storage.get(id); ok {
+			return blob, true
+		}
+	}
+	return nil, false
+}
+
+// tileOf returns index of the tile which contains given point. Points outside of the frame are assigned to the nearest tile
+func (tracker *TiledTracker) tileOf(pt Point) int {
+	col := int(math.Floor(pt.X / tracker.tileWidth))
+	row := int(math.Floor(pt.Y / tracker.tileHeight))
+	col = clampInt(col, 0, tracker.cols-1)
+	row = clampInt(row, 0, tracker.rows-1)
+	return row*tracker.cols + col
+}
+
+func clampInt(value, min, max int) int {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
+
+// MatchObjects matches new objects with existing ones
+func (tracker *TiledTracker) MatchObjects(newObjects []*SimpleBlob) error {
+	_, err := tracker.MatchObjectsWithResultCtx(context.Background(), newObjects)
+	return err
+}
+
+// MatchObjectsCtx is the same as MatchObjects, but matching could be cancelled or bounded by deadline via context.
+// See SimpleTracker.MatchObjectsCtx for details
+func (tracker *TiledTracker) MatchObjectsCtx(ctx context.Context, newObjects []*SimpleBlob) error {
+	_, err := tracker.MatchObjectsWithResultCtx(ctx, newObjects)
+	return err
+}
+
+// MatchObjectsWithResult matches new objects with existing ones and returns per-frame association report.
+// Detection indices in the report refer to the input slice
+func (tracker *TiledTracker) MatchObjectsWithResult(newObjects []*SimpleBlob) (*MatchResult, error) {
+	return tracker.MatchObjectsWithResultCtx(context.Background(), newObjects)
+}
+
+// MatchObjectsWithResultCtx is the same as MatchObjectsWithResult, but matching could be cancelled or bounded by deadline via context.
+// Cancellation is checked in each tile independently, so some tiles could be processed when the error is returned
+func (tracker *TiledTracker) MatchObjectsWithResultCtx(ctx context.Context, newObjects []*SimpleBlob) (*MatchResult, error) {
+	return tracker.matchObjects(ctx, time.Time{}, newObjects)
+}
+
+// tiledFrame is frame which has been validated and distributed between tiles
+type tiledFrame struct {
+	newObjects   []*SimpleBlob
+	tilesObjects [][]*SimpleBlob
+	// Indices of tiles' detections in the input slice
+	tilesIndices [][]int
+}
+
+// partition validates frame and distributes detections between tiles
+func (tracker *TiledTracker) partition(newObjects []*SimpleBlob) (*tiledFrame, error) {
+	if err := validateBlobs(newObjects); err != nil {
+		return nil, err
+	}
+	frame := &tiledFrame{
+		newObjects:   newObjects,
+		tilesObjects: make([][]*SimpleBlob, len(tracker.tiles)),
+		tilesIndices: make([][]int, len(tracker.tiles)),
+	}
+	for i, newObject := range newObjects {
+		tileIdx := tracker.tileOf(newObject.currentCenter)
+		frame.tilesObjects[tileIdx] = append(frame.tilesObjects[tileIdx], newObject)
+		frame.tilesIndices[tileIdx] = append(frame.tilesIndices[tileIdx], i)
+	}
+	return frame, nil
+}
+
+// matchObjects processes single frame in all tiles. Zero timestamp means that the frame is stamped with the current time
+func (tracker *TiledTracker) matchObjects(ctx context.Context, timestamp time.Time, newObjects []*SimpleBlob) (*MatchResult, error) {
+	frame, err := tracker.partition(newObjects)
+	if err != nil {
+		return nil, err
+	}
+	return tracker.matchPartitioned(ctx, timestamp, frame)
+}
+
+// matchPartitioned processes single frame which has been distributed between tiles already
+func (tracker *TiledTracker) matchPartitioned(ctx context.Context, timestamp time.Time, frame *tiledFrame) (*MatchResult, error) {
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	newObjects, tilesObjects, tilesIndices := frame.newObjects, frame.tilesObjects, frame.tilesIndices
+	results := make([]*MatchResult, len(tracker.tiles))
+	errs := make([]error, len(tracker.tiles))
+	var wg sync.WaitGroup
+	for i := range tracker.tiles {
+		wg.Add(1)
+		go func(tileIdx int) {
+			defer wg.Done()
+			results[tileIdx] = NewMatchResult()
+			errs[tileIdx] = tracker.tiles[tileIdx].matchObjects(ctx, timestamp, tilesObjects[tileIdx], results[tileIdx])
+		}(i)
+	}
+	wg.Wait()
+	for tileIdx, err := range errs {
+		if err != nil {
+			return nil, errors.Wrapf(err, "Can't match objects in tile %d", tileIdx)
+		}
+	}
+
+	// Map tile-local detection indices to the global ones
+	for tileIdx, result := range results {
+		for i := range result.Matched {
+			result.Matched[i].DetectionIndex = tilesIndices[tileIdx][result.Matched[i].DetectionIndex]
+		}
+		for i := range result.Created {
+			result.Created[i].DetectionIndex = tilesIndices[tileIdx][result.Created[i].DetectionIndex]
+		}
+		for i := range result.Rejected {
+			result.Rejected[i] = tilesIndices[tileIdx][result.Rejected[i]]
+		}
+	}
+
+	err := tracker.handOff(newObjects, results)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := NewMatchResult()
+	merged.Frame = results[0].Frame
+	merged.Timestamp = timestamp
+	for _, result := range results {
+		merged.Matched = append(merged.Matched, result.Matched...)
+		merged.Created = append(merged.Created, result.Created...)
+		merged.Unmatched = append(merged.Unmatched, result.Unmatched...)
+		merged.Rejected = append(merged.Rejected, result.Rejected...)
+		// Tiles are processed concurrently: stages durations are summed up, while total duration is the one of the slowest tile
+		merged.Timings.Predict += result.Timings.Predict
+		merged.Timings.CostMatrix += result.Timings.CostMatrix
+		merged.Timings.Assignment += result.Timings.Assignment
+		merged.Timings.Cleanup += result.Timings.Cleanup
+		if result.Timings.Total > merged.Timings.Total {
+			merged.Timings.Total = result.Timings.Total
+		}
+	}
+	return merged, nil
+}
+
+// neighbours returns indices of tiles adjacent to the given one
+func (tracker *TiledTracker) neighbours(tileIdx int) []int {
+	row := tileIdx / tracker.cols
+	col := tileIdx % tracker.cols
+	neighbours := make([]int, 0, 8)
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if dx == 0 && dy == 0 {
+				continue
+			}
+			r, c := row+dy, col+dx
+			if r < 0 || r >= tracker.rows || c < 0 || c >= tracker.cols {
+				continue
+			}
+			neighbours = append(neighbours, r*tracker.cols+c)
+		}
+	}
+	return neighbours
+}
+
+// handOff replaces tracks which have just been created in some tile with unmatched tracks of neighbouring tiles
+// when the latter ones are close enough: it means that object has crossed the tile boundary
+func (tracker *TiledTracker) handOff(newObjects []*SimpleBlob, results []*MatchResult) error {
+	for tileIdx, tile := range tracker.tiles {
+		created := results[tileIdx].Created[:0]
+		for _, createdTrack := range results[tileIdx].Created {
+			newObject := newObjects[createdTrack.DetectionIndex]
+			neighbourIdx, unmatchedIdx, distance := tracker.closestUnmatched(tileIdx, newObject, results)
+			if neighbourIdx < 0 {
+				created = append(created, createdTrack)
+				continue
+			}
+			neighbourResult := results[neighbourIdx]
+			oldID := neighbourResult.Unmatched[unmatchedIdx]
+			oldBlob, ok := tracker.tiles[neighbourIdx].detachTrack(oldID)
+			if !ok {
+				return errors.Wrapf(ErrUnknownTrack, "Can't find blob with id %s in tile %d", oldID.String(), neighbourIdx)
+			}
+			if transient, ok := tile.detachTrack(createdTrack.TrackID); ok && transient.confirmed {
+				// Subscribers have been notified about the track already
+				tile.callbacks.onRemoved(transient)
+				tile.emit(EventTrackRemoved, transient)
+				tile.releaseDisplayID(transient)
+			}
+			tile.counters.tracksCreated--
+			err := tile.updateTrack(oldBlob, newObject)
+			if err != nil {
+				return errors.Wrapf(err, "Can't update blob with id %s during hand-off", oldID.String())
+			}
+			// Keep the same no-match semantics as for objects matched inside single tile
+			oldBlob.IncNoMatch()
+			newObject.id = oldID
+			tile.addTrack(oldBlob)
+			neighbourResult.Unmatched = append(neighbourResult.Unmatched[:unmatchedIdx], neighbourResult.Unmatched[unmatchedIdx+1:]...)
+			results[tileIdx].Matched = append(results[tileIdx].Matched, MatchedTrack{
+				TrackID:        oldID,
+				DetectionIndex: createdTrack.DetectionIndex,
+				Score:          distance,
+			})
+		}
+		results[tileIdx].Created = created
+	}
+	return nil
+}
+
+// closestUnmatched searches neighbouring tiles for the closest unmatched track which could be matched with the new object.
+// Returns -1 as tile index if there is no such track
+func (tracker *TiledTracker) closestUnmatched(tileIdx int, newObject *SimpleBlob, results []*MatchResult) (int, int, float64) {
+	bestTile, bestUnmatched := -1, -1
+	bestDistance := math.MaxFloat64
+	for _, neighbourIdx := range tracker.neighbours(tileIdx) {
+		neighbour := tracker.tiles[neighbourIdx]
+		for i, unmatchedID := range results[neighbourIdx].Unmatched {
+			object, ok := neighbour.storage.get(unmatchedID)
+			if !ok {
+				// Track has been removed during clean up
+				continue
+			}
+			distance := neighbour.distanceBetween(newObject, object)
+			if distance < bestDistance && neighbour.withinGate(newObject, object, distance) {
+				bestTile, bestUnmatched, bestDistance = neighbourIdx, i, distance
+			}
+		}
+	}
+	return bestTile, bestUnmatched, bestDistance
+}
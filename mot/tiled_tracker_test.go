@@ -0,0 +1,81 @@
+package mot
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestTiledTrackerHandOff(t *testing.T) {
+	tracker := NewTiledTracker(400.0, 200.0, 2, 1, 15.0, 5)
+	dt := 1.0 / 25.0
+	var firstID, secondID [16]byte
+	// Object moves from the left tile to the right one. Another object stays in the right tile
+	for frame := 0; frame < 16; frame++ {
+		x := 160.0 + float64(frame)*5.0
+		blobs := []*SimpleBlob{
+			NewSimpleBlobWithTime(NewRect(x, 50.0, 20.0, 20.0), dt),
+			NewSimpleBlobWithTime(NewRect(350.0, 150.0, 20.0, 20.0), dt),
+		}
+		err := tracker.MatchObjects(blobs)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if frame == 0 {
+			firstID = blobs[0].GetID()
+			secondID = blobs[1].GetID()
+			continue
+		}
+		if blobs[0].GetID() != firstID {
+			t.Errorf("object has changed its identifier on frame %d", frame)
+			return
+		}
+		if blobs[1].GetID() != secondID {
+			t.Errorf("static object has changed its identifier on frame %d", frame)
+			return
+		}
+	}
+	if len(tracker.GetTracks()) != 2 {
+		t.Errorf("incorrect number of tracks: %d, expected: %d", len(tracker.GetTracks()), 2)
+	}
+	if _, ok := tracker.tiles[1].storage.get(firstID); !ok {
+		t.Errorf("object should be handed off to the right tile")
+	}
+}
+
+func TestTiledTrackerHandOffCallbacks(t *testing.T) {
+	tracker := NewTiledTracker(400.0, 200.0, 2, 1, 15.0, 5)
+	alive := make(map[uuid.UUID]int)
+	for _, tile := range tracker.tiles {
+		tile.SetOnTrackCreated(func(track *SimpleBlob) { alive[track.GetID()]++ })
+		tile.SetOnTrackRemoved(func(track *SimpleBlob) { alive[track.GetID()]-- })
+	}
+	dt := 1.0 / 25.0
+	for frame := 0; frame < 16; frame++ {
+		x := 160.0 + float64(frame)*5.0
+		err := tracker.MatchObjects([]*SimpleBlob{NewSimpleBlobWithTime(NewRect(x, 50.0, 20.0, 20.0), dt)})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	tracks := tracker.GetTracks()
+	if len(tracks) != 1 {
+		t.Errorf("incorrect number of tracks: %d, expected: %d", len(tracks), 1)
+		return
+	}
+	// Transient tracks which have been replaced during hand-off must be reported as removed
+	for id, count := range alive {
+		expected := 0
+		if id == tracks[0].GetID() {
+			expected = 1
+		}
+		if count != expected {
+			t.Errorf("incorrect balance of created / removed callbacks for track %v: %d, expected: %d", id, count, expected)
+		}
+	}
+	if len(alive) < 2 {
+		t.Errorf("incorrect number of created tracks: %d, expected transient track to be created during hand-off", len(alive))
+	}
+}
@@ -0,0 +1,107 @@
+package mot
+
+import (
+	"context"
+	"runtime/pprof"
+	"time"
+)
+
+// Names of MatchObjects stages. They are used as values of "mot_stage" profiler label
+const (
+	StagePredict    = "predict"
+	StageCostMatrix = "cost_matrix"
+	StageAssignment = "assignment"
+	StageCleanup    = "cleanup"
+)
+
+// FrameTimings holds durations of MatchObjects stages for single frame.
+// Association is split into two stages: CostMatrix (evaluation of detection-track candidates) and Assignment (resolving of conflicts and updates)
+type FrameTimings struct {
+	// Prediction of tracks positions
+	Predict time.Duration
+	// Computation of distances between detections and tracks
+	CostMatrix time.Duration
+	// Resolving of conflicts and updating of matched tracks
+	Assignment time.Duration
+	// Registration of new tracks and removal of outdated ones
+	Cleanup time.Duration
+	// Whole MatchObjects call
+	Total time.Duration
+}
+
+// SetTimingCallback sets function which is called at the end of each MatchObjects call with durations of its stages.
+// Pass nil to disable it
+func (tracker *SimpleTracker) SetTimingCallback(callback func(timings FrameTimings)) {
+	tracker.timingCallback = callback
+}
+
+// SetProfilerLabels enables or disables pprof labels for MatchObjects stages.
+// Label key is "mot_stage" and values are StagePredict, StageCostMatrix, StageAssignment and StageCleanup.
+// Stage label is added to labels of the context passed to MatchObjectsCtx, and goroutine labels are set back to them after each stage
+// (the same way as pprof.Do does). Goroutine labels which are not carried by the context can't be read back, so they are dropped:
+// labelled callers should pass their context (e.g. the one given by pprof.Do) to MatchObjectsCtx
+func (tracker *SimpleTracker) SetProfilerLabels(enabled bool) {
+	tracker.profilerLabels = enabled
+}
+
+// frameStages tracks current stage of MatchObjects call
+type frameStages struct {
+	// Context of the caller. Its labels are restored when stage is finished
+	ctx        context.Context
+	labels     bool
+	timings    FrameTimings
+	stage      string
+	stageStart time.Time
+	hooks      []FrameHooks
+}
+
+// begin prepares stages tracking for the new frame
+func (stages *frameStages) begin(ctx context.Context, labels bool, hooks []FrameHooks) {
+	stages.ctx = ctx
+	stages.labels = labels
+	stages.hooks = hooks
+	stages.timings = FrameTimings{}
+	stages.stage = ""
+}
+
+// enter finishes current stage (if any) and starts the given one
+func (stages *frameStages) enter(stage string) {
+	stages.leave()
+	stages.stage = stage
+	for _, hooks := range stages.hooks {
+		if hooks.BeforeStage != nil {
+			hooks.BeforeStage(stage)
+		}
+	}
+	stages.stageStart = time.Now()
+	if stages.labels {
+		pprof.SetGoroutineLabels(pprof.WithLabels(stages.ctx, pprof.Labels("mot_stage", stage)))
+	}
+}
+
+// leave finishes current stage (if any)
+func (stages *frameStages) leave() {
+	if stages.stage == "" {
+		return
+	}
+	elapsed := time.Since(stages.stageStart)
+	switch stages.stage {
+	case StagePredict:
+		stages.timings.Predict += elapsed
+	case StageCostMatrix:
+		stages.timings.CostMatrix += elapsed
+	case StageAssignment:
+		stages.timings.Assignment += elapsed
+	case StageCleanup:
+		stages.timings.Cleanup += elapsed
+	}
+	if stages.labels {
+		pprof.SetGoroutineLabels(stages.ctx)
+	}
+	for _, hooks := range stages.hooks {
+		if hooks.AfterStage != nil {
+			hooks.AfterStage(stages.stage)
+		}
+	}
+	stages.stage = ""
+}
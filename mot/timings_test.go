@@ -0,0 +1,83 @@
+package mot
+
+import (
+	"bytes"
+	"context"
+	"runtime/pprof"
+	"testing"
+)
+
+func TestTimingCallback(t *testing.T) {
+	tracker := NewNewSimpleTracker(15.0, 5)
+	tracker.SetProfilerLabels(true)
+	calls := 0
+	var last FrameTimings
+	tracker.SetTimingCallback(func(timings FrameTimings) {
+		calls++
+		last = timings
+	})
+	dt := 1.0 / 25.0
+	for frame := 0; frame < 3; frame++ {
+		err := tracker.MatchObjects([]*SimpleBlob{NewSimpleBlobWithTime(NewRect(10.0+float64(frame), 10.0, 20.0, 20.0), dt)})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if calls != 3 {
+		t.Errorf("incorrect number of callback calls: %d, expected: %d", calls, 3)
+	}
+	stagesTotal := last.Predict + last.CostMatrix + last.Assignment + last.Cleanup
+	if last.Total < stagesTotal {
+		t.Errorf("total duration %v should not be less than sum of stages %v", last.Total, stagesTotal)
+	}
+}
+
+func TestResultTimings(t *testing.T) {
+	tracker := NewNewSimpleTracker(15.0, 5)
+	dt := 1.0 / 25.0
+	result, err := tracker.MatchObjectsWithResult([]*SimpleBlob{NewSimpleBlobWithTime(NewRect(10.0, 10.0, 20.0, 20.0), dt)})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if result.Timings.Total <= 0 {
+		t.Errorf("incorrect total duration: %v, expected positive value", result.Timings.Total)
+	}
+	stagesTotal := result.Timings.Predict + result.Timings.CostMatrix + result.Timings.Assignment + result.Timings.Cleanup
+	if result.Timings.Total < stagesTotal {
+		t.Errorf("total duration %v should not be less than sum of stages %v", result.Timings.Total, stagesTotal)
+	}
+	stats := tracker.Stats()
+	if stats.LastFrameTimings != result.Timings {
+		t.Errorf("incorrect last frame timings: %v, expected: %v", stats.LastFrameTimings, result.Timings)
+	}
+	if stats.LastFrameTimings.Total != stats.LastFrameLatency {
+		t.Errorf("incorrect last frame total duration: %v, expected: %v", stats.LastFrameTimings.Total, stats.LastFrameLatency)
+	}
+}
+
+func TestProfilerLabelsKeepOuterLabels(t *testing.T) {
+	tracker := NewNewSimpleTracker(15.0, 5)
+	tracker.SetProfilerLabels(true)
+	pprof.Do(context.Background(), pprof.Labels("mot_test_outer", "value"), func(ctx context.Context) {
+		for frame := 0; frame < 2; frame++ {
+			err := tracker.MatchObjectsCtx(ctx, []*SimpleBlob{NewSimpleBlob(NewRect(10.0+float64(frame), 10.0, 20.0, 20.0))})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		var profile bytes.Buffer
+		if err := pprof.Lookup("goroutine").WriteTo(&profile, 1); err != nil {
+			t.Error(err)
+			return
+		}
+		if !bytes.Contains(profile.Bytes(), []byte(`"mot_test_outer":"value"`)) {
+			t.Errorf("outer goroutine labels should survive the frame")
+		}
+		if bytes.Contains(profile.Bytes(), []byte(`"mot_stage"`)) {
+			t.Errorf("stage label should be removed after the frame")
+		}
+	})
+}
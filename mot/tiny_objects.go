@@ -0,0 +1,38 @@
+package mot
+
+import (
+	"math"
+
+	"github.com/LdDl/mot-go/mot/metrics"
+)
+
+// NormalizedCenterCost returns cost function for tiny objects (e.g. vehicles on aerial/drone footage which are a few pixels in size),
+// where boxes of consecutive frames barely overlap. Cost is the distance between detection's center and the closest of track's current
+// and predicted centers, divided by track's diagonal. Since diagonal of a few-pixel box is noisy, it is bounded from below by
+// minObjectSize (meters) converted to pixels via ground sample distance gsd (meters per pixel); non-positive gsd disables the bound.
+// Pairs with cost greater than maxDistance are rejected
+func NormalizedCenterCost(gsd, minObjectSize, maxDistance float64) CostFunction {
+	minScale := 0.0
+	if gsd > 0 {
+		minScale = minObjectSize / gsd
+	}
+	return func(track *SimpleBlob, detection *SimpleBlob) (float64, bool) {
+		scale := math.Max(track.diagonal, minScale)
+		current := metrics.NormalizedCenterDistance(detection.currentCenter.X, detection.currentCenter.Y, track.currentCenter.X, track.currentCenter.Y, scale)
+		predicted := metrics.NormalizedCenterDistance(detection.currentCenter.X, detection.currentCenter.Y, track.predictedNextPosition.X, track.predictedNextPosition.Y, scale)
+		cost := math.Min(current, predicted)
+		if cost > maxDistance {
+			return 0, false
+		}
+		return cost, true
+	}
+}
+
+// SetTinyObjects configures tracker for tiny objects: association is done via NormalizedCenterCost and detections
+// are not filtered by size or aspect ratio (otherwise few-pixel boxes would be rejected)
+func (tracker *SimpleTracker) SetTinyObjects(gsd, minObjectSize, maxDistance float64) {
+	tracker.SetCostFunction(NormalizedCenterCost(gsd, minObjectSize, maxDistance))
+	tracker.SetMinBoxArea(0)
+	tracker.SetMinBoxSize(0, 0)
+	tracker.SetAspectRatioRange(0, 0)
+}
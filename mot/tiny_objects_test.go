@@ -0,0 +1,44 @@
+package mot
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormalizedCenterCost(t *testing.T) {
+	// 0.5 meters per pixel, objects are at least 4 meters (8 pixels) in size
+	cost := NormalizedCenterCost(0.5, 4.0, 1.5)
+	track := NewSimpleBlob(Rectangle{X: 100.0, Y: 100.0, Width: 3.0, Height: 4.0})
+	// 12 pixels away from track's center (no overlap at all)
+	detection := NewSimpleBlob(Rectangle{X: 112.0, Y: 100.0, Width: 3.0, Height: 4.0})
+	value, ok := cost(track, detection)
+	if !ok {
+		t.Errorf("pair should be accepted")
+	}
+	if math.Abs(value-1.5) > 1e-9 {
+		t.Errorf("incorrect normalized cost: %v, expected: %v", value, 1.5)
+	}
+	far := NewSimpleBlob(Rectangle{X: 120.0, Y: 100.0, Width: 3.0, Height: 4.0})
+	if _, ok := cost(track, far); ok {
+		t.Errorf("pair should be rejected")
+	}
+}
+
+func TestTinyObjects(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinBoxArea(100.0), WithTinyObjects(0.5, 4.0, 1.5), WithMaxNoMatch(2))
+	if tracker.GetMinBoxArea() != 0 {
+		t.Errorf("incorrect min box area: %v, expected: %v", tracker.GetMinBoxArea(), 0.0)
+	}
+	for i := 0; i < 5; i++ {
+		// Object moves 6 pixels per frame while being 3x4 pixels, so boxes never overlap
+		detection := NewSimpleBlob(Rectangle{X: 100.0 + 6.0*float64(i), Y: 100.0, Width: 3.0, Height: 4.0})
+		err := tracker.MatchObjects([]*SimpleBlob{detection})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if len(tracker.Objects) != 1 {
+		t.Errorf("incorrect number of tracks: %v, expected: %v", len(tracker.Objects), 1)
+	}
+}
@@ -0,0 +1,83 @@
+package mot
+
+import (
+	"github.com/google/uuid"
+)
+
+// FinalizedTrack is complete record of track which has been removed from tracker
+type FinalizedTrack struct {
+	// Identifier of track
+	TrackID uuid.UUID
+	// Compact display identifier of track (zero if display identifiers are disabled)
+	DisplayID int
+	// Frame on which track has been registered
+	CreatedAt FrameStamp
+	// Frame on which track has been matched for the last time
+	LastSeenAt FrameStamp
+	// Track points (limited by blob's max track length). It is copy, so it could be kept after callback returns
+	Trajectory []Point
+	// Frames of track points (aligned with Trajectory)
+	Stamps []FrameStamp
+	// Bounding boxes of the latest track points (aligned with the end of Trajectory)
+	BBoxes []Rectangle
+	// Bounding box at the moment of removal
+	LastBBox Rectangle
+	// Total number of frames in which track has been matched
+	Matches int
+	// Length of trajectory
+	PathLength float64
+	// The smallest rectangle which contains trajectory
+	Bounds Rectangle
+	// Whether track has got identifier of removed track via resurrection or ReID recovery.
+	// Earlier part of such track has been finalized already under the same TrackID, so this record covers only the part after revival
+	Revived bool
+}
+
+// SetOnTrackFinalized sets function which is called once for each visible track when it is removed from tracker,
+// so the track could be archived before tracker drops it. Setting the function also enables recording of bounding boxes history.
+// If identifier of removed track is given back to new track (see SetResurrectionWindow and SetReIDRecovery),
+// the revived track is finalized separately with FinalizedTrack.Revived set, so such records should be merged by TrackID.
+// Nil disables the callback
+func (tracker *SimpleTracker) SetOnTrackFinalized(fn func(track FinalizedTrack)) {
+	tracker.finalizer = fn
+}
+
+// SetOnTrackFinalized sets finalization callback for all tiles. Tiles are processed in parallel, so the function must be safe for concurrent use
+func (tracker *TiledTracker) SetOnTrackFinalized(fn func(track FinalizedTrack)) {
+	for _, tile := range tracker.tiles {
+		tile.SetOnTrackFinalized(fn)
+	}
+}
+
+// recordBBox appends track's current bounding box to its history if finalization callback is set
+func (tracker *SimpleTracker) recordBBox(track *SimpleBlob) {
+	if tracker.finalizer == nil {
+		return
+	}
+	track.bboxHistory = appendBounded(track.bboxHistory, track.currentBBox, track.maxTrackLen)
+}
+
+// finalize passes complete record of the removed track to the finalization callback
+func (tracker *SimpleTracker) finalize(track *SimpleBlob) {
+	if tracker.finalizer == nil {
+		return
+	}
+	pathLength := 0.0
+	for i := 1; i < len(track.track); i++ {
+		pathLength += euclideanDistance(track.track[i-1], track.track[i])
+	}
+	tracker.finalizer(FinalizedTrack{
+		TrackID:    track.id,
+		DisplayID:  track.displayID,
+		CreatedAt:  track.createdAt,
+		LastSeenAt: track.lastSeenAt,
+		Trajectory: append([]Point{}, track.track...),
+		Stamps:     append([]FrameStamp{}, track.trackStamps...),
+		BBoxes:     append([]Rectangle{}, track.bboxHistory...),
+		LastBBox:   track.currentBBox,
+		Matches:    track.matches,
+		PathLength: pathLength,
+		Bounds:     TrackBounds(track.track),
+		Revived:    track.revived,
+	})
+}
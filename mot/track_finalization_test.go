@@ -0,0 +1,104 @@
+package mot
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestTrackFinalization(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(1))
+	finalized := make([]FinalizedTrack, 0)
+	tracker.SetOnTrackFinalized(func(track FinalizedTrack) {
+		finalized = append(finalized, track)
+	})
+	frames := [][]*SimpleBlob{
+		{NewSimpleBlob(Rectangle{X: 10.0, Y: 10.0, Width: 20.0, Height: 20.0})},
+		{NewSimpleBlob(Rectangle{X: 13.0, Y: 14.0, Width: 20.0, Height: 20.0})},
+		{},
+		{},
+		{},
+	}
+	var trackID uuid.UUID
+	for i, newObjects := range frames {
+		result, err := tracker.MatchObjectsWithResult(newObjects)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if i == 0 {
+			trackID = result.Created[0].TrackID
+		}
+	}
+	if len(finalized) != 1 {
+		t.Errorf("incorrect number of finalized tracks: %d, expected: %d", len(finalized), 1)
+		return
+	}
+	track := finalized[0]
+	if track.TrackID != trackID {
+		t.Errorf("incorrect finalized track: %s, expected: %s", track.TrackID, trackID)
+	}
+	if track.Matches != 2 {
+		t.Errorf("incorrect number of matches: %d, expected: %d", track.Matches, 2)
+	}
+	if len(track.Trajectory) != 2 || len(track.Stamps) != 2 || len(track.BBoxes) != 2 {
+		t.Errorf("incorrect history lengths: %d points, %d stamps, %d boxes, expected: 2 of each", len(track.Trajectory), len(track.Stamps), len(track.BBoxes))
+		return
+	}
+	if track.CreatedAt.Frame != 0 || track.LastSeenAt.Frame != 1 {
+		t.Errorf("incorrect stamps: %v %v, expected frames: %d %d", track.CreatedAt, track.LastSeenAt, 0, 1)
+	}
+	expectedLength := euclideanDistance(track.Trajectory[0], track.Trajectory[1])
+	if math.Abs(track.PathLength-expectedLength) > 1e-9 {
+		t.Errorf("incorrect path length: %f, expected: %f", track.PathLength, expectedLength)
+	}
+	if track.BBoxes[0] != (Rectangle{X: 10.0, Y: 10.0, Width: 20.0, Height: 20.0}) {
+		t.Errorf("incorrect first bounding box: %v", track.BBoxes[0])
+	}
+}
+
+func TestTrackFinalizationResurrected(t *testing.T) {
+	tracker := NewSimpleTracker(WithMinDistThreshold(15.0), WithMaxNoMatch(1), WithResurrectionWindow(5))
+	finalized := make([]FinalizedTrack, 0)
+	tracker.SetOnTrackFinalized(func(track FinalizedTrack) {
+		finalized = append(finalized, track)
+	})
+	frames := [][]*SimpleBlob{
+		{NewSimpleBlob(Rectangle{X: 10.0, Y: 10.0, Width: 20.0, Height: 20.0})},
+		{NewSimpleBlob(Rectangle{X: 12.0, Y: 10.0, Width: 20.0, Height: 20.0})},
+		{},
+		{},
+		{},
+		// Object reappears within resurrection window
+		{NewSimpleBlob(Rectangle{X: 14.0, Y: 10.0, Width: 20.0, Height: 20.0})},
+		{NewSimpleBlob(Rectangle{X: 16.0, Y: 10.0, Width: 20.0, Height: 20.0})},
+		{},
+		{},
+		{},
+	}
+	for _, newObjects := range frames {
+		err := tracker.MatchObjects(newObjects)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if len(finalized) != 2 {
+		t.Errorf("incorrect number of finalized records: %d, expected: %d", len(finalized), 2)
+		return
+	}
+	if finalized[0].TrackID != finalized[1].TrackID {
+		t.Errorf("resurrected track should keep identifier: %s, expected: %s", finalized[1].TrackID, finalized[0].TrackID)
+	}
+	if finalized[0].Revived || !finalized[1].Revived {
+		t.Errorf("incorrect revival marks: %v %v, expected: %v %v", finalized[0].Revived, finalized[1].Revived, false, true)
+	}
+	// Records must not overlap
+	if finalized[0].LastSeenAt.Frame >= finalized[1].CreatedAt.Frame {
+		t.Errorf("records overlap: first ends at %v, second starts at %v", finalized[0].LastSeenAt, finalized[1].CreatedAt)
+	}
+	if len(finalized[1].Trajectory) != 2 {
+		t.Errorf("incorrect trajectory length of revived track: %d, expected: %d", len(finalized[1].Trajectory), 2)
+	}
+}
@@ -0,0 +1,72 @@
+package mot
+
+import "github.com/google/uuid"
+
+// trackStorage keeps tracks in the order of their registration and provides lookup by identifier
+type trackStorage struct {
+	tracks []*SimpleBlob
+	index  map[uuid.UUID]int
+}
+
+func newTrackStorage() trackStorage {
+	return trackStorage{
+		tracks: make([]*SimpleBlob, 0),
+		index:  make(map[uuid.UUID]int),
+	}
+}
+
+// len returns number of stored tracks
+func (storage *trackStorage) len() int {
+	return len(storage.tracks)
+}
+
+// get returns track by its identifier
+func (storage *trackStorage) get(id uuid.UUID) (*SimpleBlob, bool) {
+	idx, ok := storage.index[id]
+	if !ok {
+		return nil, false
+	}
+	return storage.tracks[idx], true
+}
+
+// add appends track to the end of storage
+func (storage *trackStorage) add(track *SimpleBlob) {
+	storage.index[track.id] = len(storage.tracks)
+	storage.tracks = append(storage.tracks, track)
+}
+
+// remove deletes track by its identifier preserving order of remaining tracks
+func (storage *trackStorage) remove(id uuid.UUID) (*SimpleBlob, bool) {
+	idx, ok := storage.index[id]
+	if !ok {
+		return nil, false
+	}
+	track := storage.tracks[idx]
+	copy(storage.tracks[idx:], storage.tracks[idx+1:])
+	storage.tracks[len(storage.tracks)-1] = nil
+	storage.tracks = storage.tracks[:len(storage.tracks)-1]
+	delete(storage.index, id)
+	for i := idx; i < len(storage.tracks); i++ {
+		storage.index[storage.tracks[i].id] = i
+	}
+	return track, true
+}
+
+// retain keeps only tracks for which keep returns true (preserving order). Removed tracks are passed to onRemove
+func (storage *trackStorage) retain(keep func(track *SimpleBlob) bool, onRemove func(track *SimpleBlob)) {
+	n := 0
+	for _, track := range storage.tracks {
+		if !keep(track) {
+			delete(storage.index, track.id)
+			onRemove(track)
+			continue
+		}
+		storage.tracks[n] = track
+		storage.index[track.id] = n
+		n++
+	}
+	for i := n; i < len(storage.tracks); i++ {
+		storage.tracks[i] = nil
+	}
+	storage.tracks = storage.tracks[:n]
+}
@@ -0,0 +1,58 @@
+package mot
+
+import "time"
+
+// TrackerStats is summary of tracker health
+type TrackerStats struct {
+	// Number of processed frames
+	FramesProcessed int
+	// Total number of tracks which have been created
+	TracksCreated int
+	// Total number of tracks which have been removed
+	TracksRemoved int
+	// Number of confirmed tracks which have been matched on the last frame (the same tracks are returned by SimpleTracker.GetActiveTracks)
+	ActiveTracks int
+	// Average number of matched tracks per frame
+	AvgMatchesPerFrame float64
+	// Duration of the last MatchObjects call
+	LastFrameLatency time.Duration
+	// Total number of dropped frames which have been compensated (see SimpleTracker.MatchObjectsAfterDrop)
+	FramesDropped int
+	// Number of frames on which latency budget has been exceeded, so part of detections has been matched greedily
+	DegradedFrames int
+	// Whether latency budget has been exceeded on the last frame
+	LastFrameDegraded bool
+	// Durations of the last MatchObjects call stages
+	LastFrameTimings FrameTimings
+}
+
+// trackerCounters accumulates data needed for TrackerStats
+type trackerCounters struct {
+	framesProcessed   int
+	tracksCreated     int
+	tracksRemoved     int
+	matchesTotal      int
+	lastFrameLatency  time.Duration
+	framesDropped     int
+	degradedFrames    int
+	lastFrameDegraded bool
+	lastFrameTimings  FrameTimings
+}
+
+func (counters *trackerCounters) stats(activeTracks int) TrackerStats {
+	stats := TrackerStats{
+		FramesProcessed:   counters.framesProcessed,
+		TracksCreated:     counters.tracksCreated,
+		TracksRemoved:     counters.tracksRemoved,
+		ActiveTracks:      activeTracks,
+		LastFrameLatency:  counters.lastFrameLatency,
+		LastFrameTimings:  counters.lastFrameTimings,
+		FramesDropped:     counters.framesDropped,
+		DegradedFrames:    counters.degradedFrames,
+		LastFrameDegraded: counters.lastFrameDegraded,
+	}
+	if counters.framesProcessed > 0 {
+		stats.AvgMatchesPerFrame = float64(counters.matchesTotal) / float64(counters.framesProcessed)
+	}
+	return stats
+}
@@ -0,0 +1,226 @@
+package mot
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Lane is counting area of TrafficCounter: vehicle is counted when its center crosses the line while being inside of the zone
+type Lane struct {
+	Name string
+	// Counting line
+	Line Segment
+	// Zone of lane. Polygon without points means that the whole frame is the zone
+	Zone Polygon
+}
+
+// TrafficCount is number of vehicles of some class which have crossed the lane's line in some direction
+type TrafficCount struct {
+	Lane      string
+	Class     string
+	Direction CrossingDirection
+	Count     int
+}
+
+// TrafficInterval is aggregated counts for time interval [Start; End)
+type TrafficInterval struct {
+	Start  time.Time
+	End    time.Time
+	Counts []TrafficCount
+}
+
+// trafficKey identifies counter: lane is index of lane
+type trafficKey struct {
+	lane      int
+	class     string
+	direction CrossingDirection
+}
+
+// TrafficCounter counts vehicles per lane, class and direction and aggregates counts over fixed time intervals.
+// It is driven by tracker events: subscribe it via tracker.OnEvent(counter.Handle) (or EventStream).
+// Each track is counted at most once per lane. Intervals are aligned to multiples of interval duration and are closed
+// by the first event whose timestamp is beyond the interval, so timestamps should come from MatchObjectsAt for offline processing.
+// It is safe for concurrent use (e.g. with TiledTracker)
+type TrafficCounter struct {
+	mu       sync.Mutex
+	lanes    []Lane
+	interval time.Duration
+	// Last known center of each track
+	positions map[uuid.UUID]Point
+	// Lanes (indices) where each track has been counted already
+	counted map[uuid.UUID]map[int]struct{}
+	start   time.Time
+	current map[trafficKey]int
+	totals  map[trafficKey]int
+	// Closed intervals (bounded by maxIntervals)
+	completed    []TrafficInterval
+	maxIntervals int
+	onInterval   func(interval TrafficInterval)
+}
+
+// NewTrafficCounter creates counter for given lanes. Counts are aggregated over intervals of given duration (zero disables
+// aggregation, so there is single never-ending interval). Up to maxIntervals closed intervals are kept (zero means no limit).
+// Returns ErrInvalidGeometry if some lane has no name, duplicate name or degenerate line
+func NewTrafficCounter(lanes []Lane, interval time.Duration, maxIntervals int) (*TrafficCounter, error) {
+	names := make(map[string]struct{}, len(lanes))
+	for i, lane := range lanes {
+		if lane.Name == "" {
+			return nil, errors.Wrapf(ErrInvalidGeometry, "Lane at index %d has no name", i)
+		}
+		if _, ok := names[lane.Name]; ok {
+			return nil, errors.Wrapf(ErrInvalidGeometry, "Duplicate lane name '%s'", lane.Name)
+		}
+		names[lane.Name] = struct{}{}
+		if lane.Line.Length() == 0 {
+			return nil, errors.Wrapf(ErrInvalidGeometry, "Line of lane '%s' has zero length", lane.Name)
+		}
+	}
+	if interval < 0 {
+		interval = 0
+	}
+	return &TrafficCounter{
+		lanes:        append([]Lane{}, lanes...),
+		interval:     interval,
+		positions:    make(map[uuid.UUID]Point),
+		counted:      make(map[uuid.UUID]map[int]struct{}),
+		current:      make(map[trafficKey]int),
+		totals:       make(map[trafficKey]int),
+		maxIntervals: maxIntervals,
+	}, nil
+}
+
+// OnInterval sets function which is called (under counter's lock) each time interval is closed
+func (counter *TrafficCounter) OnInterval(fn func(interval TrafficInterval)) {
+	counter.mu.Lock()
+	defer counter.mu.Unlock()
+	counter.onInterval = fn
+}
+
+// Handle processes tracker event
+func (counter *TrafficCounter) Handle(event Event) {
+	counter.mu.Lock()
+	defer counter.mu.Unlock()
+	counter.advance(event.Timestamp)
+	if event.Type == EventTrackRemoved {
+		delete(counter.positions, event.TrackID)
+		delete(counter.counted, event.TrackID)
+		return
+	}
+	center := event.BBox.Center()
+	previous, ok := counter.positions[event.TrackID]
+	counter.positions[event.TrackID] = center
+	if !ok {
+		return
+	}
+	for i, lane := range counter.lanes {
+		if _, ok := counter.counted[event.TrackID][i]; ok {
+			continue
+		}
+		if len(lane.Zone.Points) > 0 && !lane.Zone.Contains(center) {
+			continue
+		}
+		direction, ok := lane.Line.Crossing(previous, center)
+		if !ok {
+			continue
+		}
+		if counter.counted[event.TrackID] == nil {
+			counter.counted[event.TrackID] = make(map[int]struct{})
+		}
+		counter.counted[event.TrackID][i] = struct{}{}
+		key := trafficKey{lane: i, class: event.Class, direction: direction}
+		counter.current[key]++
+		counter.totals[key]++
+	}
+}
+
+// advance closes current interval if timestamp is beyond it
+func (counter *TrafficCounter) advance(timestamp time.Time) {
+	if counter.interval == 0 || timestamp.IsZero() {
+		if counter.start.IsZero() {
+			counter.start = timestamp
+		}
+		return
+	}
+	if counter.start.IsZero() {
+		counter.start = timestamp.Truncate(counter.interval)
+		return
+	}
+	if timestamp.Before(counter.start.Add(counter.interval)) {
+		return
+	}
+	closed := counter.snapshot()
+	if counter.maxIntervals > 0 {
+		counter.completed = appendBounded(counter.completed, closed, counter.maxIntervals)
+	} else {
+		counter.completed = append(counter.completed, closed)
+	}
+	if counter.onInterval != nil {
+		counter.onInterval(closed)
+	}
+	counter.current = make(map[trafficKey]int)
+	counter.start = timestamp.Truncate(counter.interval)
+}
+
+// snapshot returns current interval
+func (counter *TrafficCounter) snapshot() TrafficInterval {
+	interval := TrafficInterval{
+		Start:  counter.start,
+		Counts: counter.counts(counter.current),
+	}
+	if counter.interval > 0 {
+		interval.End = counter.start.Add(counter.interval)
+	}
+	return interval
+}
+
+// counts converts counters to sorted list (by lane order, class and direction)
+func (counter *TrafficCounter) counts(values map[trafficKey]int) []TrafficCount {
+	keys := make([]trafficKey, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].lane != keys[j].lane {
+			return keys[i].lane < keys[j].lane
+		}
+		if keys[i].class != keys[j].class {
+			return keys[i].class < keys[j].class
+		}
+		return keys[i].direction < keys[j].direction
+	})
+	counts := make([]TrafficCount, len(keys))
+	for i, key := range keys {
+		counts[i] = TrafficCount{
+			Lane:      counter.lanes[key.lane].Name,
+			Class:     key.class,
+			Direction: key.direction,
+			Count:     values[key],
+		}
+	}
+	return counts
+}
+
+// Current returns counts of the interval which is not closed yet
+func (counter *TrafficCounter) Current() TrafficInterval {
+	counter.mu.Lock()
+	defer counter.mu.Unlock()
+	return counter.snapshot()
+}
+
+// Intervals returns closed intervals (oldest first)
+func (counter *TrafficCounter) Intervals() []TrafficInterval {
+	counter.mu.Lock()
+	defer counter.mu.Unlock()
+	return append([]TrafficInterval{}, counter.completed...)
+}
+
+// Totals returns counts since counter creation
+func (counter *TrafficCounter) Totals() []TrafficCount {
+	counter.mu.Lock()
+	defer counter.mu.Unlock()
+	return counter.counts(counter.totals)
+}
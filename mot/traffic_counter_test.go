@@ -0,0 +1,89 @@
+package mot
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSegmentCrossing(t *testing.T) {
+	line := NewSegment(Point{X: 0.0, Y: 0.0}, Point{X: 0.0, Y: 10.0})
+	direction, ok := line.Crossing(Point{X: 2.0, Y: 5.0}, Point{X: -2.0, Y: 5.0})
+	if !ok || direction != CrossingForward {
+		t.Errorf("incorrect crossing: %v (found: %v), expected: %v", direction, ok, CrossingForward)
+	}
+	direction, ok = line.Crossing(Point{X: -2.0, Y: 5.0}, Point{X: 2.0, Y: 5.0})
+	if !ok || direction != CrossingBackward {
+		t.Errorf("incorrect crossing: %v (found: %v), expected: %v", direction, ok, CrossingBackward)
+	}
+	if _, ok := line.Crossing(Point{X: 2.0, Y: 15.0}, Point{X: -2.0, Y: 15.0}); ok {
+		t.Errorf("movement beyond segment should not be crossing")
+	}
+	if _, ok := line.Crossing(Point{X: 2.0, Y: 5.0}, Point{X: 0.0, Y: 5.0}); ok {
+		t.Errorf("stopping on segment should not be crossing")
+	}
+}
+
+func TestTrafficCounter(t *testing.T) {
+	_, err := NewTrafficCounter([]Lane{{Name: "a", Line: NewSegment(Point{X: 1.0, Y: 1.0}, Point{X: 1.0, Y: 1.0})}}, time.Minute, 0)
+	if !errors.Is(err, ErrInvalidGeometry) {
+		t.Errorf("incorrect error: %v, expected: %v", err, ErrInvalidGeometry)
+	}
+	// Two lanes split by x = 100, counting line is y = 200
+	leftZone, _ := NewPolygon([]Point{{X: 0.0, Y: 0.0}, {X: 100.0, Y: 0.0}, {X: 100.0, Y: 400.0}, {X: 0.0, Y: 400.0}})
+	rightZone, _ := NewPolygon([]Point{{X: 100.0, Y: 0.0}, {X: 200.0, Y: 0.0}, {X: 200.0, Y: 400.0}, {X: 100.0, Y: 400.0}})
+	line := NewSegment(Point{X: 0.0, Y: 200.0}, Point{X: 200.0, Y: 200.0})
+	counter, err := NewTrafficCounter([]Lane{
+		{Name: "left", Line: line, Zone: leftZone},
+		{Name: "right", Line: line, Zone: rightZone},
+	}, time.Minute, 0)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	tracker := NewSimpleTracker(WithMinDistThreshold(30.0))
+	tracker.OnEvent(counter.Handle)
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 10; i++ {
+		car := NewSimpleBlob(Rectangle{X: 40.0, Y: 100.0 + 20.0*float64(i), Width: 20.0, Height: 20.0})
+		car.SetClass("car")
+		truck := NewSimpleBlob(Rectangle{X: 140.0, Y: 300.0 - 20.0*float64(i), Width: 20.0, Height: 20.0})
+		truck.SetClass("truck")
+		err := tracker.MatchObjectsAt(start.Add(time.Duration(i)*time.Second), []*SimpleBlob{car, truck})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	totals := counter.Totals()
+	expected := []TrafficCount{
+		{Lane: "left", Class: "car", Direction: CrossingForward, Count: 1},
+		{Lane: "right", Class: "truck", Direction: CrossingBackward, Count: 1},
+	}
+	if len(totals) != len(expected) {
+		t.Errorf("incorrect number of counts: %v, expected: %v", totals, expected)
+		return
+	}
+	for i := range expected {
+		if totals[i] != expected[i] {
+			t.Errorf("incorrect count at index %d: %v, expected: %v", i, totals[i], expected[i])
+		}
+	}
+	// Event of the next minute closes the interval
+	err = tracker.MatchObjectsAt(start.Add(time.Minute), nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	intervals := counter.Intervals()
+	if len(intervals) != 1 {
+		t.Errorf("incorrect number of intervals: %v, expected: %v", len(intervals), 1)
+		return
+	}
+	if !intervals[0].Start.Equal(start) || !intervals[0].End.Equal(start.Add(time.Minute)) || len(intervals[0].Counts) != 2 {
+		t.Errorf("incorrect interval: %v", intervals[0])
+	}
+	if current := counter.Current(); len(current.Counts) != 0 {
+		t.Errorf("incorrect current counts: %v, expected none", current.Counts)
+	}
+}
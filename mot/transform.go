@@ -0,0 +1,257 @@
+package mot
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// Transform maps points of one plane to another (e.g. camera motion compensation or ground-plane mapping)
+type Transform interface {
+	// Apply maps single point
+	Apply(pt Point) Point
+	// ApplyRect maps corners of rectangle and returns their bounding box
+	ApplyRect(rect Rectangle) Rectangle
+}
+
+// Affine is 2x3 affine transformation matrix:
+//
+//	| x' |   | M[0][0] M[0][1] M[0][2] |   | x |
+//	| y' | = | M[1][0] M[1][1] M[1][2] | * | y |
+//	                                        | 1 |
+type Affine struct {
+	M [2][3]float64
+}
+
+// NewAffine creates affine transformation from 2x3 matrix given in row-major order
+func NewAffine(a, b, c, d, e, f float64) Affine {
+	return Affine{M: [2][3]float64{{a, b, c}, {d, e, f}}}
+}
+
+// NewAffineIdentity creates affine transformation which does not change points
+func NewAffineIdentity() Affine {
+	return NewAffine(1, 0, 0, 0, 1, 0)
+}
+
+// Apply maps single point
+func (transform Affine) Apply(pt Point) Point {
+	m := transform.M
+	return Point{
+		X: m[0][0]*pt.X + m[0][1]*pt.Y + m[0][2],
+		Y: m[1][0]*pt.X + m[1][1]*pt.Y + m[1][2],
+	}
+}
+
+// ApplyRect maps corners of rectangle and returns their bounding box
+func (transform Affine) ApplyRect(rect Rectangle) Rectangle {
+	return applyRect(transform, rect)
+}
+
+// Invert returns inverse transformation. Returns ErrInvalidGeometry if matrix is singular
+func (transform Affine) Invert() (Affine, error) {
+	m := transform.M
+	det := m[0][0]*m[1][1] - m[0][1]*m[1][0]
+	if det == 0 || !isFinite(det) {
+		return Affine{}, errors.Wrap(ErrInvalidGeometry, "Affine transformation is singular")
+	}
+	a := m[1][1] / det
+	b := -m[0][1] / det
+	d := -m[1][0] / det
+	e := m[0][0] / det
+	return NewAffine(a, b, -(a*m[0][2] + b*m[1][2]), d, e, -(d*m[0][2] + e*m[1][2])), nil
+}
+
+// Homography is 3x3 perspective transformation matrix. Points are mapped in homogeneous coordinates and then normalized
+type Homography struct {
+	M [3][3]float64
+}
+
+// NewHomography creates perspective transformation from 3x3 matrix
+func NewHomography(m [3][3]float64) Homography {
+	return Homography{M: m}
+}
+
+// Apply maps single point. Points which are mapped to infinity (e.g. lying on the horizon line) get infinite coordinates
+func (transform Homography) Apply(pt Point) Point {
+	m := transform.M
+	w := m[2][0]*pt.X + m[2][1]*pt.Y + m[2][2]
+	x := m[0][0]*pt.X + m[0][1]*pt.Y + m[0][2]
+	y := m[1][0]*pt.X + m[1][1]*pt.Y + m[1][2]
+	if w == 0 {
+		return Point{X: math.Inf(sign(x)), Y: math.Inf(sign(y))}
+	}
+	return Point{X: x / w, Y: y / w}
+}
+
+// ApplyRect maps corners of rectangle and returns their bounding box
+func (transform Homography) ApplyRect(rect Rectangle) Rectangle {
+	return applyRect(transform, rect)
+}
+
+// Invert returns inverse transformation. Returns ErrInvalidGeometry if matrix is singular
+func (transform Homography) Invert() (Homography, error) {
+	m := transform.M
+	cofactors := [3][3]float64{
+		{m[1][1]*m[2][2] - m[1][2]*m[2][1], m[0][2]*m[2][1] - m[0][1]*m[2][2], m[0][1]*m[1][2] - m[0][2]*m[1][1]},
+		{m[1][2]*m[2][0] - m[1][0]*m[2][2], m[0][0]*m[2][2] - m[0][2]*m[2][0], m[0][2]*m[1][0] - m[0][0]*m[1][2]},
+		{m[1][0]*m[2][1] - m[1][1]*m[2][0], m[0][1]*m[2][0] - m[0][0]*m[2][1], m[0][0]*m[1][1] - m[0][1]*m[1][0]},
+	}
+	det := m[0][0]*cofactors[0][0] + m[0][1]*cofactors[1][0] + m[0][2]*cofactors[2][0]
+	if det == 0 || !isFinite(det) {
+		return Homography{}, errors.Wrap(ErrInvalidGeometry, "Homography is singular")
+	}
+	for i := range cofactors {
+		for j := range cofactors[i] {
+			cofactors[i][j] /= det
+		}
+	}
+	return Homography{M: cofactors}, nil
+}
+
+// FindHomography estimates homography which maps src points to dst points (at least 4 pairs, no 3 of first 4 points should be collinear).
+// For more than 4 pairs least squares solution is found. Points are normalized beforehand for numerical stability.
+// Returns ErrLengthMismatch if number of points differs and ErrInvalidGeometry if there are not enough points or they are degenerate
+func FindHomography(src, dst []Point) (Homography, error) {
+	if len(src) != len(dst) {
+		return Homography{}, errors.Wrapf(ErrLengthMismatch, "Got %d source points and %d destination points", len(src), len(dst))
+	}
+	if len(src) < 4 {
+		return Homography{}, errors.Wrapf(ErrInvalidGeometry, "Homography needs at least 4 pairs of points, got %d", len(src))
+	}
+	srcNorm, srcPoints, err := normalizePoints(src)
+	if err != nil {
+		return Homography{}, err
+	}
+	dstNorm, dstPoints, err := normalizePoints(dst)
+	if err != nil {
+		return Homography{}, err
+	}
+	// Each pair gives two equations on h11..h32 (h33 = 1):
+	//	x*h11 + y*h12 + h13 - x*u*h31 - y*u*h32 = u
+	//	x*h21 + y*h22 + h23 - x*v*h31 - y*v*h32 = v
+	// which are solved via normal equations
+	var ata [8][9]float64
+	for i := range srcPoints {
+		x, y := srcPoints[i].X, srcPoints[i].Y
+		u, v := dstPoints[i].X, dstPoints[i].Y
+		rows := [2][9]float64{
+			{x, y, 1, 0, 0, 0, -x * u, -y * u, u},
+			{0, 0, 0, x, y, 1, -x * v, -y * v, v},
+		}
+		for _, row := range rows {
+			for j := 0; j < 8; j++ {
+				for k := 0; k < 9; k++ {
+					ata[j][k] += row[j] * row[k]
+				}
+			}
+		}
+	}
+	h, ok := solveLinear8(ata)
+	if !ok {
+		return Homography{}, errors.Wrap(ErrInvalidGeometry, "Points are degenerate")
+	}
+	normalized := NewHomography([3][3]float64{{h[0], h[1], h[2]}, {h[3], h[4], h[5]}, {h[6], h[7], 1}})
+	dstDenorm, err := dstNorm.Invert()
+	if err != nil {
+		return Homography{}, err
+	}
+	result := multiplyHomography(multiplyHomography(dstDenorm, normalized), srcNorm)
+	if result.M[2][2] == 0 || !isFinite(result.M[2][2]) {
+		return Homography{}, errors.Wrap(ErrInvalidGeometry, "Points are degenerate")
+	}
+	for i := range result.M {
+		for j := range result.M[i] {
+			result.M[i][j] /= result.M[2][2]
+		}
+	}
+	return result, nil
+}
+
+// normalizePoints moves centroid of points to the origin and scales them so mean distance to the origin is sqrt(2).
+// Returns the normalizing transformation and normalized points
+func normalizePoints(points []Point) (Homography, []Point, error) {
+	cx, cy := 0.0, 0.0
+	for _, pt := range points {
+		cx += pt.X
+		cy += pt.Y
+	}
+	cx /= float64(len(points))
+	cy /= float64(len(points))
+	meanDistance := 0.0
+	for _, pt := range points {
+		meanDistance += math.Hypot(pt.X-cx, pt.Y-cy)
+	}
+	meanDistance /= float64(len(points))
+	if meanDistance == 0 || !isFinite(meanDistance) {
+		return Homography{}, nil, errors.Wrap(ErrInvalidGeometry, "Points are degenerate")
+	}
+	scale := math.Sqrt2 / meanDistance
+	normalization := NewHomography([3][3]float64{{scale, 0, -scale * cx}, {0, scale, -scale * cy}, {0, 0, 1}})
+	normalized := make([]Point, len(points))
+	for i, pt := range points {
+		normalized[i] = normalization.Apply(pt)
+	}
+	return normalization, normalized, nil
+}
+
+// multiplyHomography returns composition of transformations: b is applied first
+func multiplyHomography(a, b Homography) Homography {
+	var m [3][3]float64
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				m[i][j] += a.M[i][k] * b.M[k][j]
+			}
+		}
+	}
+	return Homography{M: m}
+}
+
+// solveLinear8 solves 8x8 linear system given as augmented matrix via Gaussian elimination with partial pivoting
+func solveLinear8(m [8][9]float64) ([8]float64, bool) {
+	var solution [8]float64
+	for col := 0; col < 8; col++ {
+		pivot := col
+		for row := col + 1; row < 8; row++ {
+			if math.Abs(m[row][col]) > math.Abs(m[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(m[pivot][col]) < 1e-12 {
+			return solution, false
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+		for row := col + 1; row < 8; row++ {
+			factor := m[row][col] / m[col][col]
+			for k := col; k < 9; k++ {
+				m[row][k] -= factor * m[col][k]
+			}
+		}
+	}
+	for row := 7; row >= 0; row-- {
+		sum := m[row][8]
+		for k := row + 1; k < 8; k++ {
+			sum -= m[row][k] * solution[k]
+		}
+		solution[row] = sum / m[row][row]
+	}
+	return solution, true
+}
+
+// applyRect maps corners of rectangle and returns their bounding box
+func applyRect(transform Transform, rect Rectangle) Rectangle {
+	corners := []Point{
+		transform.Apply(Point{X: rect.X, Y: rect.Y}),
+		transform.Apply(Point{X: rect.X + rect.Width, Y: rect.Y}),
+		transform.Apply(Point{X: rect.X + rect.Width, Y: rect.Y + rect.Height}),
+		transform.Apply(Point{X: rect.X, Y: rect.Y + rect.Height}),
+	}
+	return Polygon{Points: corners}.BoundingBox()
+}
+
+func sign(value float64) int {
+	if value < 0 {
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,89 @@
+package mot
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestAffine(t *testing.T) {
+	// Rotation by 90 degrees followed by translation
+	transform := NewAffine(0, -1, 10, 1, 0, 20)
+	pt := transform.Apply(Point{X: 1, Y: 2})
+	if math.Abs(pt.X-8) > eps || math.Abs(pt.Y-21) > eps {
+		t.Errorf("incorrect transformed point: %v, expected: %v", pt, Point{X: 8, Y: 21})
+	}
+	rect := transform.ApplyRect(Rectangle{X: 0, Y: 0, Width: 4, Height: 2})
+	expected := Rectangle{X: 8, Y: 20, Width: 2, Height: 4}
+	if math.Abs(rect.X-expected.X) > eps || math.Abs(rect.Y-expected.Y) > eps || math.Abs(rect.Width-expected.Width) > eps || math.Abs(rect.Height-expected.Height) > eps {
+		t.Errorf("incorrect transformed rectangle: %v, expected: %v", rect, expected)
+	}
+	inverse, err := transform.Invert()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	back := inverse.Apply(pt)
+	if math.Abs(back.X-1) > eps || math.Abs(back.Y-2) > eps {
+		t.Errorf("incorrect inverse transformation: %v, expected: %v", back, Point{X: 1, Y: 2})
+	}
+	_, err = NewAffine(1, 2, 0, 2, 4, 0).Invert()
+	if !errors.Is(err, ErrInvalidGeometry) {
+		t.Errorf("incorrect error for singular matrix: %v, expected: %v", err, ErrInvalidGeometry)
+	}
+}
+
+func TestHomography(t *testing.T) {
+	transform := NewHomography([3][3]float64{{2, 0, 1}, {0, 3, 2}, {0.001, 0.002, 1}})
+	src := Point{X: 100, Y: 50}
+	pt := transform.Apply(src)
+	w := 0.001*100 + 0.002*50 + 1
+	if math.Abs(pt.X-201/w) > eps || math.Abs(pt.Y-152/w) > eps {
+		t.Errorf("incorrect transformed point: %v, expected: %v", pt, Point{X: 201 / w, Y: 152 / w})
+	}
+	inverse, err := transform.Invert()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	back := inverse.Apply(pt)
+	if math.Abs(back.X-src.X) > eps || math.Abs(back.Y-src.Y) > eps {
+		t.Errorf("incorrect inverse transformation: %v, expected: %v", back, src)
+	}
+	var _ Transform = transform
+	var _ Transform = NewAffineIdentity()
+}
+
+func TestFindHomography(t *testing.T) {
+	transform := NewHomography([3][3]float64{{2, 0.1, 5}, {0.05, 3, 2}, {0.001, 0.002, 1}})
+	src := []Point{{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 100, Y: 80}, {X: 0, Y: 80}, {X: 50, Y: 30}}
+	dst := make([]Point, len(src))
+	for i, pt := range src {
+		dst[i] = transform.Apply(pt)
+	}
+	found, err := FindHomography(src, dst)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for i := range found.M {
+		for j := range found.M[i] {
+			if math.Abs(found.M[i][j]-transform.M[i][j]) > 1e-6 {
+				t.Errorf("incorrect homography element [%d][%d]: %v, expected: %v", i, j, found.M[i][j], transform.M[i][j])
+			}
+		}
+	}
+	_, err = FindHomography(src[:3], dst[:3])
+	if !errors.Is(err, ErrInvalidGeometry) {
+		t.Errorf("incorrect error for 3 points: %v, expected: %v", err, ErrInvalidGeometry)
+	}
+	_, err = FindHomography(src, dst[:4])
+	if !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("incorrect error for different number of points: %v, expected: %v", err, ErrLengthMismatch)
+	}
+	collinear := []Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: 3}}
+	_, err = FindHomography(collinear, collinear)
+	if !errors.Is(err, ErrInvalidGeometry) {
+		t.Errorf("incorrect error for collinear points: %v, expected: %v", err, ErrInvalidGeometry)
+	}
+}
@@ -0,0 +1,188 @@
+package mot
+
+import (
+	"math/rand"
+
+	"github.com/pkg/errors"
+)
+
+// TuningClip is short labeled sequence: raw detections and ground truth of each frame
+type TuningClip struct {
+	Detections  [][]Rectangle
+	GroundTruth [][]EvalObject
+}
+
+// TuningParams is configuration of tracker's thresholds
+type TuningParams struct {
+	MinDistThreshold      float64
+	MaxNoMatch            int
+	MinConsecutiveMatches int
+}
+
+// options converts parameters to tracker's options
+func (params TuningParams) options() []func(*SimpleTracker) {
+	return []func(*SimpleTracker){
+		WithMinDistThreshold(params.MinDistThreshold),
+		WithMaxNoMatch(params.MaxNoMatch),
+		WithMinConsecutiveMatches(params.MinConsecutiveMatches),
+	}
+}
+
+// TuningSpace is set of candidate values of each parameter. Empty list means that tracker's default value is used
+type TuningSpace struct {
+	MinDistThresholds     []float64
+	MaxNoMatch            []int
+	MinConsecutiveMatches []int
+}
+
+// TuningObjective is metric which tuner maximizes
+type TuningObjective uint16
+
+const (
+	// TuneMOTA maximizes multiple object tracking accuracy
+	TuneMOTA = TuningObjective(iota)
+	// TuneIDF1 maximizes identity F1 score
+	TuneIDF1
+)
+
+// TuningTrial is evaluation of single configuration
+type TuningTrial struct {
+	Params     TuningParams
+	Evaluation Evaluation
+}
+
+// score returns value of objective
+func (trial *TuningTrial) score(objective TuningObjective) float64 {
+	if objective == TuneIDF1 {
+		return trial.Evaluation.IDF1
+	}
+	return trial.Evaluation.MOTA
+}
+
+// Tuner searches for tracker's thresholds which give the best metric on labeled clip
+type Tuner struct {
+	clip      TuningClip
+	objective TuningObjective
+	// Boxes are matched with ground truth when their IoU is not less than this value
+	iouThreshold float64
+	// Options which are applied to each tracker before tuned parameters (e.g. distance mode)
+	baseOptions []func(*SimpleTracker)
+}
+
+// NewTuner creates tuner for the given clip. Base options are applied to each evaluated tracker before tuned parameters.
+// Returns ErrLengthMismatch if number of frames of detections and ground truth differs
+func NewTuner(clip TuningClip, objective TuningObjective, baseOptions ...func(*SimpleTracker)) (*Tuner, error) {
+	if len(clip.Detections) != len(clip.GroundTruth) {
+		return nil, errors.Wrapf(ErrLengthMismatch, "Got %d detections frames and %d ground truth frames", len(clip.Detections), len(clip.GroundTruth))
+	}
+	return &Tuner{
+		clip:         clip,
+		objective:    objective,
+		iouThreshold: 0.5,
+		baseOptions:  baseOptions,
+	}, nil
+}
+
+// SetIoUThreshold sets min IoU of tracker's box and ground truth box to consider them matched. Default is 0.5
+func (tuner *Tuner) SetIoUThreshold(iouThreshold float64) {
+	tuner.iouThreshold = iouThreshold
+}
+
+// Run evaluates tracker with given parameters on the clip
+func (tuner *Tuner) Run(params TuningParams) (TuningTrial, error) {
+	tracker := NewSimpleTracker(append(append([]func(*SimpleTracker){}, tuner.baseOptions...), params.options()...)...)
+	hypotheses := make([][]EvalObject, len(tuner.clip.Detections))
+	for frame, boxes := range tuner.clip.Detections {
+		newObjects := make([]*SimpleBlob, len(boxes))
+		for i, bbox := range boxes {
+			newObjects[i] = NewSimpleBlob(bbox)
+		}
+		err := tracker.MatchObjects(newObjects)
+		if err != nil {
+			return TuningTrial{}, errors.Wrapf(err, "Can't match objects on frame %d", frame)
+		}
+		tracks := tracker.GetActiveTracks()
+		hypotheses[frame] = make([]EvalObject, len(tracks))
+		for i, track := range tracks {
+			hypotheses[frame][i] = EvalObject{ID: track.id.String(), BBox: track.currentBBox}
+		}
+	}
+	evaluation, err := Evaluate(tuner.clip.GroundTruth, hypotheses, tuner.iouThreshold)
+	if err != nil {
+		return TuningTrial{}, err
+	}
+	return TuningTrial{Params: params, Evaluation: evaluation}, nil
+}
+
+// GridSearch evaluates every combination of the space and returns the best one with all trials (in evaluation order).
+// Ties are resolved in favour of the earlier trial
+func (tuner *Tuner) GridSearch(space TuningSpace) (TuningTrial, []TuningTrial, error) {
+	defaults := NewSimpleTracker(tuner.baseOptions...)
+	thresholds := space.MinDistThresholds
+	if len(thresholds) == 0 {
+		thresholds = []float64{defaults.minDistThreshold}
+	}
+	maxNoMatch := space.MaxNoMatch
+	if len(maxNoMatch) == 0 {
+		maxNoMatch = []int{defaults.maxNoMatch}
+	}
+	minMatches := space.MinConsecutiveMatches
+	if len(minMatches) == 0 {
+		minMatches = []int{defaults.minConsecutiveMatches}
+	}
+	candidates := make([]TuningParams, 0, len(thresholds)*len(maxNoMatch)*len(minMatches))
+	for _, threshold := range thresholds {
+		for _, noMatch := range maxNoMatch {
+			for _, matches := range minMatches {
+				candidates = append(candidates, TuningParams{MinDistThreshold: threshold, MaxNoMatch: noMatch, MinConsecutiveMatches: matches})
+			}
+		}
+	}
+	return tuner.search(candidates)
+}
+
+// RandomSearch evaluates given number of configurations sampled from the space (with repetitions) and returns the best one with all trials.
+// It is cheaper than GridSearch for large spaces. Seed makes search reproducible
+func (tuner *Tuner) RandomSearch(space TuningSpace, trials int, seed int64) (TuningTrial, []TuningTrial, error) {
+	defaults := NewSimpleTracker(tuner.baseOptions...)
+	random := rand.New(rand.NewSource(seed))
+	candidates := make([]TuningParams, 0, trials)
+	for i := 0; i < trials; i++ {
+		params := TuningParams{
+			MinDistThreshold:      defaults.minDistThreshold,
+			MaxNoMatch:            defaults.maxNoMatch,
+			MinConsecutiveMatches: defaults.minConsecutiveMatches,
+		}
+		if len(space.MinDistThresholds) > 0 {
+			params.MinDistThreshold = space.MinDistThresholds[random.Intn(len(space.MinDistThresholds))]
+		}
+		if len(space.MaxNoMatch) > 0 {
+			params.MaxNoMatch = space.MaxNoMatch[random.Intn(len(space.MaxNoMatch))]
+		}
+		if len(space.MinConsecutiveMatches) > 0 {
+			params.MinConsecutiveMatches = space.MinConsecutiveMatches[random.Intn(len(space.MinConsecutiveMatches))]
+		}
+		candidates = append(candidates, params)
+	}
+	return tuner.search(candidates)
+}
+
+// search evaluates candidates and picks the best one
+func (tuner *Tuner) search(candidates []TuningParams) (TuningTrial, []TuningTrial, error) {
+	trials := make([]TuningTrial, 0, len(candidates))
+	best := -1
+	for _, params := range candidates {
+		trial, err := tuner.Run(params)
+		if err != nil {
+			return TuningTrial{}, nil, err
+		}
+		trials = append(trials, trial)
+		if best < 0 || trial.score(tuner.objective) > trials[best].score(tuner.objective) {
+			best = len(trials) - 1
+		}
+	}
+	if best < 0 {
+		return TuningTrial{}, trials, nil
+	}
+	return trials[best], trials, nil
+}
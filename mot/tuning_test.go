@@ -0,0 +1,48 @@
+package mot
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTuner(t *testing.T) {
+	clip := TuningClip{}
+	for frame := 0; frame < 10; frame++ {
+		a := Rectangle{X: 10.0 + 10.0*float64(frame), Y: 10.0, Width: 10.0, Height: 10.0}
+		b := Rectangle{X: 10.0 + 10.0*float64(frame), Y: 200.0, Width: 10.0, Height: 10.0}
+		clip.Detections = append(clip.Detections, []Rectangle{a, b})
+		clip.GroundTruth = append(clip.GroundTruth, []EvalObject{{ID: "a", BBox: a}, {ID: "b", BBox: b}})
+	}
+	tuner, err := NewTuner(clip, TuneIDF1)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	// Objects move by 10 pixels per frame, so too small threshold breaks tracks on every frame
+	best, trials, err := tuner.GridSearch(TuningSpace{MinDistThresholds: []float64{1.0, 30.0}, MaxNoMatch: []int{1, 5}})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(trials) != 4 {
+		t.Errorf("incorrect number of trials: %v, expected: %v", len(trials), 4)
+	}
+	if best.Params.MinDistThreshold != 30.0 {
+		t.Errorf("incorrect best threshold: %v, expected: %v", best.Params.MinDistThreshold, 30.0)
+	}
+	if best.Evaluation.IDF1 < 0.9 || best.Evaluation.IDSwitches != 0 {
+		t.Errorf("incorrect best evaluation: %+v", best.Evaluation)
+	}
+	randomBest, randomTrials, err := tuner.RandomSearch(TuningSpace{MinDistThresholds: []float64{1.0, 30.0}}, 6, 42)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(randomTrials) != 6 || randomBest.Evaluation.IDF1 > best.Evaluation.IDF1 {
+		t.Errorf("incorrect random search: %v trials, best %+v", len(randomTrials), randomBest.Evaluation)
+	}
+	_, err = NewTuner(TuningClip{Detections: clip.Detections}, TuneMOTA)
+	if !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("incorrect error: %v, expected: %v", err, ErrLengthMismatch)
+	}
+}
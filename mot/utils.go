@@ -0,0 +1,84 @@
+package mot
+
+import "math"
+
+// IoU returns intersection over union of two rectangles
+func IoU(a, b Rectangle) float64 {
+	return IoUXYWH(a.X, a.Y, a.Width, a.Height, b.X, b.Y, b.Width, b.Height)
+}
+
+// IoA returns intersection over area of the first rectangle: it shows which part of a is covered by b
+func IoA(a, b Rectangle) float64 {
+	return IoAXYWH(a.X, a.Y, a.Width, a.Height, b.X, b.Y, b.Width, b.Height)
+}
+
+// GIoU returns generalized intersection over union of two rectangles. Value is in [-1; 1] range:
+// it keeps decreasing when rectangles move away from each other while IoU stays zero
+func GIoU(a, b Rectangle) float64 {
+	intersection := a.Intersect(b).Area()
+	union := a.Area() + b.Area() - intersection
+	hull := a.Union(b).Area()
+	if union <= 0 || hull <= 0 {
+		return 0
+	}
+	return intersection/union - (hull-union)/hull
+}
+
+// DIoU returns distance intersection over union of two rectangles:
+// IoU penalized by squared distance between centers normalized by squared diagonal of enclosing rectangle
+func DIoU(a, b Rectangle) float64 {
+	return IoU(a, b) - centersPenalty(a, b)
+}
+
+// CIoU returns complete intersection over union of two rectangles: DIoU additionally penalized by aspect ratios inconsistency
+func CIoU(a, b Rectangle) float64 {
+	iou := IoU(a, b)
+	diou := iou - centersPenalty(a, b)
+	if a.Height <= 0 || b.Height <= 0 {
+		return diou
+	}
+	v := 4.0 / (math.Pi * math.Pi) * math.Pow(math.Atan(b.Width/b.Height)-math.Atan(a.Width/a.Height), 2)
+	if v == 0 {
+		return diou
+	}
+	alpha := v / ((1 - iou) + v)
+	return diou - alpha*v
+}
+
+// centersPenalty returns squared distance between centers divided by squared diagonal of enclosing rectangle
+func centersPenalty(a, b Rectangle) float64 {
+	hull := a.Union(b)
+	diagonalSquared := hull.Width*hull.Width + hull.Height*hull.Height
+	if diagonalSquared <= 0 {
+		return 0
+	}
+	ca, cb := a.Center(), b.Center()
+	return ((ca.X-cb.X)*(ca.X-cb.X) + (ca.Y-cb.Y)*(ca.Y-cb.Y)) / diagonalSquared
+}
+
+// IoUMatrix returns matrix of IoU values: element [i][j] is IoU of a[i] and b[j]
+func IoUMatrix(a, b []Rectangle) [][]float64 {
+	flat := IoUMatrixFlat(a, b, nil)
+	matrix := make([][]float64, len(a))
+	for i := range matrix {
+		matrix[i] = flat[i*len(b) : (i+1)*len(b) : (i+1)*len(b)]
+	}
+	return matrix
+}
+
+// IoUMatrixFlat is the same as IoUMatrix, but returns row-major flat slice: element [i*len(b)+j] is IoU of a[i] and b[j].
+// dst is reused if it has enough capacity, so the same buffer could be passed for each frame
+func IoUMatrixFlat(a, b []Rectangle, dst []float64) []float64 {
+	size := len(a) * len(b)
+	if cap(dst) < size {
+		dst = make([]float64, size)
+	}
+	dst = dst[:size]
+	for i := range a {
+		row := dst[i*len(b) : (i+1)*len(b)]
+		for j := range b {
+			row[j] = IoU(a[i], b[j])
+		}
+	}
+	return dst
+}
@@ -0,0 +1,75 @@
+package mot
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIoU(t *testing.T) {
+	a := Rectangle{X: 0.0, Y: 0.0, Width: 10.0, Height: 10.0}
+	b := Rectangle{X: 5.0, Y: 0.0, Width: 10.0, Height: 10.0}
+	iou := IoU(a, b)
+	if math.Abs(iou-50.0/150.0) > eps {
+		t.Errorf("incorrect IoU: %v, expected: %v", iou, 50.0/150.0)
+	}
+	if iou := IoU(a, Rectangle{X: 20.0, Y: 20.0, Width: 5.0, Height: 5.0}); iou != 0 {
+		t.Errorf("incorrect IoU of disjoint rectangles: %v, expected: %v", iou, 0.0)
+	}
+}
+
+func TestOverlapMetrics(t *testing.T) {
+	a := Rectangle{X: 0.0, Y: 0.0, Width: 10.0, Height: 10.0}
+	b := Rectangle{X: 5.0, Y: 0.0, Width: 10.0, Height: 10.0}
+	far := Rectangle{X: 20.0, Y: 0.0, Width: 10.0, Height: 10.0}
+	if ioa := IoA(a, b); math.Abs(ioa-0.5) > eps {
+		t.Errorf("incorrect IoA: %v, expected: %v", ioa, 0.5)
+	}
+	// Enclosing rectangle is 15x10 and union is 150, so penalty is zero
+	if giou := GIoU(a, b); math.Abs(giou-50.0/150.0) > eps {
+		t.Errorf("incorrect GIoU: %v, expected: %v", giou, 50.0/150.0)
+	}
+	if giou := GIoU(a, far); math.Abs(giou-(-100.0/300.0)) > eps {
+		t.Errorf("incorrect GIoU of disjoint rectangles: %v, expected: %v", giou, -100.0/300.0)
+	}
+	expectedDIoU := 50.0/150.0 - 25.0/325.0
+	if diou := DIoU(a, b); math.Abs(diou-expectedDIoU) > eps {
+		t.Errorf("incorrect DIoU: %v, expected: %v", diou, expectedDIoU)
+	}
+	// Aspect ratios are the same, so CIoU equals to DIoU
+	if ciou := CIoU(a, b); math.Abs(ciou-expectedDIoU) > eps {
+		t.Errorf("incorrect CIoU: %v, expected: %v", ciou, expectedDIoU)
+	}
+	tall := Rectangle{X: 0.0, Y: 0.0, Width: 5.0, Height: 20.0}
+	if CIoU(a, tall) >= DIoU(a, tall) {
+		t.Errorf("CIoU should penalize different aspect ratios: %v, expected less than: %v", CIoU(a, tall), DIoU(a, tall))
+	}
+}
+
+func TestIoUMatrix(t *testing.T) {
+	a := []Rectangle{{X: 0, Y: 0, Width: 10, Height: 10}, {X: 100, Y: 100, Width: 10, Height: 10}}
+	b := []Rectangle{{X: 5, Y: 0, Width: 10, Height: 10}, {X: 0, Y: 0, Width: 10, Height: 10}, {X: 50, Y: 50, Width: 1, Height: 1}}
+	matrix := IoUMatrix(a, b)
+	if len(matrix) != len(a) {
+		t.Errorf("incorrect number of rows: %d, expected: %d", len(matrix), len(a))
+		return
+	}
+	for i := range a {
+		if len(matrix[i]) != len(b) {
+			t.Errorf("incorrect number of columns in row %d: %d, expected: %d", i, len(matrix[i]), len(b))
+			return
+		}
+		for j := range b {
+			if math.Abs(matrix[i][j]-IoU(a[i], b[j])) > eps {
+				t.Errorf("incorrect IoU at [%d][%d]: %v, expected: %v", i, j, matrix[i][j], IoU(a[i], b[j]))
+			}
+		}
+	}
+	buffer := make([]float64, 0, 16)
+	flat := IoUMatrixFlat(a, b, buffer)
+	if &flat[0] != &buffer[:1][0] {
+		t.Errorf("buffer with enough capacity should be reused")
+	}
+	if math.Abs(flat[1]-1.0) > eps {
+		t.Errorf("incorrect flat IoU at [0][1]: %v, expected: %v", flat[1], 1.0)
+	}
+}
@@ -0,0 +1,41 @@
+package mot
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// validateBlobs checks that new objects could be processed by trackers safely
+func validateBlobs(newObjects []*SimpleBlob) error {
+	for i, blob := range newObjects {
+		if blob == nil {
+			return errors.Wrapf(ErrInvalidBBox, "Blob at index %d is nil", i)
+		}
+		if blob.tracker == nil {
+			return errors.Wrapf(ErrInvalidBBox, "Blob at index %d has not been created via constructor", i)
+		}
+		if err := validateRect(blob.currentBBox); err != nil {
+			return errors.Wrapf(err, "Blob at index %d", i)
+		}
+		if !isFinite(blob.currentCenter.X) || !isFinite(blob.currentCenter.Y) {
+			return errors.Wrapf(ErrInvalidBBox, "Blob at index %d has non-finite center (%f, %f)", i, blob.currentCenter.X, blob.currentCenter.Y)
+		}
+	}
+	return nil
+}
+
+// validateRect checks that rectangle has finite coordinates and non-negative size
+func validateRect(rect Rectangle) error {
+	if !isFinite(rect.X) || !isFinite(rect.Y) || !isFinite(rect.Width) || !isFinite(rect.Height) {
+		return errors.Wrapf(ErrInvalidBBox, "Non-finite coordinates (%f, %f, %f, %f)", rect.X, rect.Y, rect.Width, rect.Height)
+	}
+	if rect.Width < 0 || rect.Height < 0 {
+		return errors.Wrapf(ErrInvalidBBox, "Negative size (%f x %f)", rect.Width, rect.Height)
+	}
+	return nil
+}
+
+func isFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
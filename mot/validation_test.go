@@ -0,0 +1,77 @@
+package mot
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestValidateBlobs(t *testing.T) {
+	valid := NewSimpleBlob(NewRect(10.0, 10.0, 20.0, 20.0))
+	cases := [][]*SimpleBlob{
+		{valid, nil},
+		{&SimpleBlob{}},
+		{NewSimpleBlob(NewRect(math.NaN(), 10.0, 20.0, 20.0))},
+		{NewSimpleBlob(NewRect(10.0, 10.0, -20.0, 20.0))},
+		{NewSimpleBlob(NewRect(10.0, math.Inf(1), 20.0, 20.0))},
+	}
+	for i, blobs := range cases {
+		tracker := NewNewSimpleTracker(15.0, 5)
+		err := tracker.MatchObjects(blobs)
+		if !errors.Is(err, ErrInvalidBBox) {
+			t.Errorf("case %d: incorrect error: %v, expected: %v", i, err, ErrInvalidBBox)
+		}
+		if len(tracker.GetTracks()) != 0 {
+			t.Errorf("case %d: invalid input should not change tracker state", i)
+		}
+	}
+	if err := validateBlobs([]*SimpleBlob{valid}); err != nil {
+		t.Errorf("valid blob should pass validation: %v", err)
+	}
+}
+
+func TestSameBlobTwice(t *testing.T) {
+	tracker := NewNewSimpleTracker(15.0, 5)
+	blob := NewSimpleBlob(NewRect(10.0, 10.0, 20.0, 20.0))
+	other := NewSimpleBlob(NewRect(500.0, 10.0, 20.0, 20.0))
+	other.SetID(blob.GetID())
+	err := tracker.MatchObjects([]*SimpleBlob{blob, other})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(tracker.GetTracks()) != 2 || len(tracker.Objects) != 2 {
+		t.Errorf("incorrect number of tracks: %d (view: %d), expected: %d", len(tracker.GetTracks()), len(tracker.Objects), 2)
+	}
+}
+
+func TestRejectedFrameKeepsState(t *testing.T) {
+	tracker := NewNewSimpleTracker(15.0, 5)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	blob := func() []*SimpleBlob {
+		return []*SimpleBlob{NewSimpleBlob(NewRect(10.0, 10.0, 20.0, 20.0))}
+	}
+	if err := tracker.MatchObjectsAt(start, blob()); err != nil {
+		t.Error(err)
+		return
+	}
+	err := tracker.MatchObjectsAfterDrop(2, []*SimpleBlob{nil})
+	if !errors.Is(err, ErrInvalidBBox) {
+		t.Errorf("incorrect error: %v, expected: %v", err, ErrInvalidBBox)
+	}
+	if tracker.pendingDrop != 2 {
+		t.Errorf("incorrect pending drop after rejected frame: %d, expected: %d", tracker.pendingDrop, 2)
+	}
+	tracker.pendingDrop = 0
+	if err := tracker.MatchObjectsAt(start.Add(time.Second), blob()); err != nil {
+		t.Error(err)
+		return
+	}
+	if dt := tracker.frameTime.Sub(tracker.prevFrameTime); dt != time.Second {
+		t.Errorf("incorrect dt: %v, expected: %v", dt, time.Second)
+	}
+	if tracker.GetFrame() != 2 {
+		t.Errorf("incorrect number of frames: %d, expected: %d", tracker.GetFrame(), 2)
+	}
+}